@@ -0,0 +1,221 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+var (
+	ErrAlbumTooSmall       = errors.New("albums must contain at least two items")
+	ErrInvalidAlbumItem    = errors.New("album items must be image or video messages")
+	ErrAlbumChildSendError = errors.New("failed to send album item")
+)
+
+// DefaultAlbumTimeout is the default value for Client.AlbumTimeout.
+const DefaultAlbumTimeout = 1 * time.Minute
+
+// Album contains the messages of an album built with [Client.BuildAlbum].
+type Album struct {
+	// The message ID that must be used when sending the parent message,
+	// as the child messages refer to the parent by ID.
+	ParentID types.MessageID
+	Parent   *waE2E.Message
+	// The IDs of the child messages. Using these IDs when sending isn't strictly necessary,
+	// but they're generated in advance for convenience.
+	ChildIDs []types.MessageID
+	Children []*waE2E.Message
+}
+
+// BuildAlbum builds an album (grouped media message) from the given media messages.
+//
+// Each item must be a message containing an ImageMessage or VideoMessage, such as the ones returned by
+// [Client.BuildMediaMessage]. The returned album can be sent with [Client.SendAlbum].
+//
+//	var items []*waE2E.Message
+//	for _, file := range files {
+//		msg, err := cli.BuildMediaMessage(ctx, whatsmeow.MediaMessageParams{Data: file})
+//		// handle error
+//		items = append(items, msg)
+//	}
+//	album, err := cli.BuildAlbum(chat, items)
+//	// handle error
+//	resps, err := cli.SendAlbum(ctx, chat, album)
+func (cli *Client) BuildAlbum(chat types.JID, items []*waE2E.Message) (*Album, error) {
+	if len(items) < 2 {
+		return nil, ErrAlbumTooSmall
+	}
+	var imageCount, videoCount uint32
+	for _, item := range items {
+		switch {
+		case item.GetImageMessage() != nil:
+			imageCount++
+		case item.GetVideoMessage() != nil:
+			videoCount++
+		default:
+			return nil, ErrInvalidAlbumItem
+		}
+	}
+	album := &Album{
+		ParentID: cli.GenerateMessageID(),
+		Parent: &waE2E.Message{
+			AlbumMessage: &waE2E.AlbumMessage{
+				ExpectedImageCount: proto.Uint32(imageCount),
+				ExpectedVideoCount: proto.Uint32(videoCount),
+			},
+		},
+		ChildIDs: make([]types.MessageID, len(items)),
+		Children: make([]*waE2E.Message, len(items)),
+	}
+	parentKey := cli.BuildMessageKey(chat, types.EmptyJID, album.ParentID)
+	for i, item := range items {
+		child := proto.Clone(item).(*waE2E.Message)
+		if child.MessageContextInfo == nil {
+			child.MessageContextInfo = &waE2E.MessageContextInfo{}
+		}
+		child.MessageContextInfo.MessageAssociation = &waE2E.MessageAssociation{
+			AssociationType:  waE2E.MessageAssociation_MEDIA_ALBUM.Enum(),
+			ParentMessageKey: parentKey,
+			MessageIndex:     proto.Int32(int32(i)),
+		}
+		album.ChildIDs[i] = cli.GenerateMessageID()
+		album.Children[i] = &waE2E.Message{
+			AssociatedChildMessage: &waE2E.FutureProofMessage{
+				Message: child,
+			},
+		}
+	}
+	return album, nil
+}
+
+// SendAlbum sends an album built with [Client.BuildAlbum].
+//
+// The parent message is sent first, followed by the children in order. If sending any message fails,
+// the remaining messages are not sent, and the responses of the messages sent so far are returned along with the error.
+// The first response is always for the parent message.
+func (cli *Client) SendAlbum(ctx context.Context, chat types.JID, album *Album) ([]SendResponse, error) {
+	resps := make([]SendResponse, 0, len(album.Children)+1)
+	resp, err := cli.SendMessage(ctx, chat, album.Parent, SendRequestExtra{ID: album.ParentID})
+	if err != nil {
+		return resps, fmt.Errorf("failed to send album parent: %w", err)
+	}
+	resps = append(resps, resp)
+	for i, child := range album.Children {
+		var extra SendRequestExtra
+		if i < len(album.ChildIDs) {
+			extra.ID = album.ChildIDs[i]
+		}
+		resp, err = cli.SendMessage(ctx, chat, child, extra)
+		if err != nil {
+			return resps, fmt.Errorf("%w #%d: %w", ErrAlbumChildSendError, i+1, err)
+		}
+		resps = append(resps, resp)
+	}
+	return resps, nil
+}
+
+type albumKey struct {
+	Chat     types.JID
+	ParentID types.MessageID
+}
+
+type pendingAlbum struct {
+	info  *types.MessageInfo
+	album *waE2E.AlbumMessage
+	items []*events.Message
+	timer *time.Timer
+}
+
+func (pa *pendingAlbum) isComplete() bool {
+	return pa.album != nil && len(pa.items) >= int(pa.album.GetExpectedImageCount()+pa.album.GetExpectedVideoCount())
+}
+
+// handleAlbumPart collects album parent and child messages and dispatches an events.Album
+// once all items have arrived or the album timeout passes.
+//
+// The event is copied before storing, as event handlers are free to modify the original.
+// Duplicate deliveries of the same item are ignored.
+func (cli *Client) handleAlbumPart(evt *events.Message) {
+	var key albumKey
+	if evt.Message.GetAlbumMessage() != nil {
+		key = albumKey{Chat: evt.Info.Chat, ParentID: evt.Info.ID}
+	} else if assoc := evt.Message.GetMessageContextInfo().GetMessageAssociation(); assoc.GetAssociationType() == waE2E.MessageAssociation_MEDIA_ALBUM {
+		key = albumKey{Chat: evt.Info.Chat, ParentID: assoc.GetParentMessageKey().GetID()}
+	} else {
+		return
+	}
+	cli.pendingAlbumsLock.Lock()
+	defer cli.pendingAlbumsLock.Unlock()
+	pa, ok := cli.pendingAlbums[key]
+	if !ok {
+		pa = &pendingAlbum{}
+		timeout := cli.AlbumTimeout
+		if timeout <= 0 {
+			timeout = DefaultAlbumTimeout
+		}
+		pa.timer = time.AfterFunc(timeout, func() {
+			cli.finishAlbum(key)
+		})
+		cli.pendingAlbums[key] = pa
+	}
+	if evt.Message.GetAlbumMessage() != nil {
+		info := evt.Info
+		pa.info = &info
+		pa.album = proto.Clone(evt.Message.GetAlbumMessage()).(*waE2E.AlbumMessage)
+	} else if !slices.ContainsFunc(pa.items, func(item *events.Message) bool {
+		return item.Info.ID == evt.Info.ID
+	}) {
+		item := *evt
+		item.Message = proto.Clone(evt.Message).(*waE2E.Message)
+		if evt.RawMessage != nil {
+			item.RawMessage = proto.Clone(evt.RawMessage).(*waE2E.Message)
+		}
+		pa.items = append(pa.items, &item)
+	}
+	if pa.isComplete() {
+		pa.timer.Stop()
+		go cli.finishAlbum(key)
+	}
+}
+
+func (cli *Client) finishAlbum(key albumKey) {
+	cli.pendingAlbumsLock.Lock()
+	pa, ok := cli.pendingAlbums[key]
+	if ok {
+		delete(cli.pendingAlbums, key)
+	}
+	cli.pendingAlbumsLock.Unlock()
+	if !ok {
+		return
+	} else if pa.info == nil {
+		cli.Log.Debugf("Album %s in %s timed out before receiving parent message (got %d items)", key.ParentID, key.Chat, len(pa.items))
+		return
+	}
+	slices.SortStableFunc(pa.items, func(a, b *events.Message) int {
+		return cmp.Compare(
+			a.Message.GetMessageContextInfo().GetMessageAssociation().GetMessageIndex(),
+			b.Message.GetMessageContextInfo().GetMessageAssociation().GetMessageIndex(),
+		)
+	})
+	cli.dispatchEvent(&events.Album{
+		Info:     *pa.info,
+		Album:    pa.album,
+		Items:    pa.items,
+		Complete: pa.isComplete(),
+	})
+}
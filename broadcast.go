@@ -141,3 +141,31 @@ func (cli *Client) GetStatusPrivacy(ctx context.Context) ([]types.StatusPrivacy,
 	}
 	return outputs, nil
 }
+
+// SetStatusPrivacy changes who status broadcasts are sent to by default.
+//
+// The list is only used with types.StatusPrivacyTypeBlacklist (everyone except the listed contacts)
+// and types.StatusPrivacyTypeWhitelist (only the listed contacts). It replaces the previously stored list of that type.
+func (cli *Client) SetStatusPrivacy(ctx context.Context, privacyType types.StatusPrivacyType, list []types.JID) error {
+	users := make([]waBinary.Node, len(list))
+	for i, jid := range list {
+		users[i] = waBinary.Node{
+			Tag:   "user",
+			Attrs: waBinary.Attrs{"jid": jid},
+		}
+	}
+	_, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "status",
+		Type:      iqSet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "privacy",
+			Content: []waBinary.Node{{
+				Tag:     "list",
+				Attrs:   waBinary.Attrs{"type": string(privacyType)},
+				Content: users,
+			}},
+		}},
+	})
+	return err
+}
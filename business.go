@@ -8,6 +8,7 @@ package whatsmeow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -53,6 +54,90 @@ func (cli *Client) GetOrderDetails(ctx context.Context, orderID, tokenBase64 str
 	return parseOrderDetailsNode(orderNode)
 }
 
+// SetBusinessProfileParams contains the fields to change in [Client.SetBusinessProfile].
+// Fields that are left nil are not changed.
+type SetBusinessProfileParams struct {
+	Address     *string
+	Email       *string
+	Description *string
+	// The full list of websites, at most two are allowed. Set to an empty non-nil slice to remove all websites.
+	Websites []string
+	// The categories of the business. Only the ID field is used.
+	Categories []types.Category
+	// The business hours. If BusinessHours is non-nil, BusinessHoursTimeZone must be set too.
+	BusinessHoursTimeZone string
+	BusinessHours         []types.BusinessHoursConfig
+}
+
+// SetBusinessProfile changes the profile info of your own WhatsApp business account.
+func (cli *Client) SetBusinessProfile(ctx context.Context, params SetBusinessProfileParams) error {
+	var content []waBinary.Node
+	addText := func(tag string, value *string) {
+		if value != nil {
+			content = append(content, waBinary.Node{Tag: tag, Content: []byte(*value)})
+		}
+	}
+	addText("address", params.Address)
+	addText("email", params.Email)
+	addText("description", params.Description)
+	if params.Websites != nil {
+		if len(params.Websites) == 0 {
+			content = append(content, waBinary.Node{Tag: "website"})
+		}
+		for _, website := range params.Websites {
+			content = append(content, waBinary.Node{Tag: "website", Content: []byte(website)})
+		}
+	}
+	if params.Categories != nil {
+		categories := make([]waBinary.Node, len(params.Categories))
+		for i, category := range params.Categories {
+			categories[i] = waBinary.Node{Tag: "category", Attrs: waBinary.Attrs{"id": category.ID}}
+		}
+		content = append(content, waBinary.Node{Tag: "categories", Content: categories})
+	}
+	if params.BusinessHours != nil {
+		if params.BusinessHoursTimeZone == "" {
+			return errors.New("business hours time zone must be set when changing business hours")
+		}
+		configs := make([]waBinary.Node, len(params.BusinessHours))
+		for i, config := range params.BusinessHours {
+			attrs := waBinary.Attrs{
+				"day_of_week": config.DayOfWeek,
+				"mode":        config.Mode,
+			}
+			if config.OpenTime != "" {
+				attrs["open_time"] = config.OpenTime
+			}
+			if config.CloseTime != "" {
+				attrs["close_time"] = config.CloseTime
+			}
+			configs[i] = waBinary.Node{Tag: "business_hours_config", Attrs: attrs}
+		}
+		content = append(content, waBinary.Node{
+			Tag:     "business_hours",
+			Attrs:   waBinary.Attrs{"timezone": params.BusinessHoursTimeZone},
+			Content: configs,
+		})
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	_, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "w:biz",
+		Type:      iqSet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "business_profile",
+			Attrs: waBinary.Attrs{
+				"v":             "3",
+				"mutation_type": "delta",
+			},
+			Content: content,
+		}},
+	})
+	return err
+}
+
 // Helper to get the string content of a child node.
 func getStringChild(node waBinary.Node, tag string) string {
 	child, ok := node.GetOptionalChildByTag(tag)
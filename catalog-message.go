@@ -0,0 +1,325 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+var (
+	ErrNoProductInfo       = errors.New("product message doesn't contain a product or catalog")
+	ErrOrderTokenMissing   = errors.New("order message doesn't contain an order ID and token")
+	ErrProductImageMissing = errors.New("product has no image")
+)
+
+// CatalogLink returns the wa.me link that opens the catalog of the given business.
+func CatalogLink(business types.JID) string {
+	return fmt.Sprintf("https://wa.me/c/%s", business.User)
+}
+
+// ProductMessageParams contains the parameters for [Client.BuildProductMessage].
+type ProductMessageParams struct {
+	// The product to share, e.g. from [Client.GetCatalog]. The ID must be set.
+	Product *types.CatalogProduct
+	// The business that owns the product. Defaults to your own phone number JID.
+	BusinessOwner types.JID
+	// The product image to include in the message. If not set, the first image in Product.ImageURLs is downloaded.
+	Image []byte
+
+	Body        string
+	Footer      string
+	ContextInfo *waE2E.ContextInfo
+}
+
+// BuildProductMessage builds a message that shares a product from a catalog.
+// The product image is uploaded as part of the message, so this requires a connection.
+func (cli *Client) BuildProductMessage(ctx context.Context, params ProductMessageParams) (*waE2E.Message, error) {
+	product := params.Product
+	if product == nil || product.ID == "" {
+		return nil, fmt.Errorf("%w: product ID is required", ErrNoProductInfo)
+	}
+	image, err := cli.buildCatalogImage(ctx, params.Image, product.ImageURLs)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &waE2E.ProductMessage_ProductSnapshot{
+		ProductImage:      image,
+		ProductID:         proto.String(product.ID),
+		Title:             proto.String(product.Name),
+		ProductImageCount: proto.Uint32(uint32(max(len(product.ImageURLs), 1))),
+	}
+	if product.Description != "" {
+		snapshot.Description = proto.String(product.Description)
+	}
+	if product.RetailerID != "" {
+		snapshot.RetailerID = proto.String(product.RetailerID)
+	}
+	if product.URL != "" {
+		snapshot.URL = proto.String(product.URL)
+	}
+	if product.Price > 0 {
+		snapshot.PriceAmount1000 = proto.Int64(product.Price)
+		snapshot.CurrencyCode = proto.String(product.Currency)
+	}
+	msg := &waE2E.ProductMessage{
+		Product:          snapshot,
+		BusinessOwnerJID: proto.String(cli.getBusinessOwner(params.BusinessOwner).String()),
+		ContextInfo:      params.ContextInfo,
+	}
+	if params.Body != "" {
+		msg.Body = proto.String(params.Body)
+	}
+	if params.Footer != "" {
+		msg.Footer = proto.String(params.Footer)
+	}
+	return &waE2E.Message{ProductMessage: msg}, nil
+}
+
+// CatalogMessageParams contains the parameters for [Client.BuildCatalogMessage].
+type CatalogMessageParams struct {
+	// The business whose catalog is shared. Defaults to your own phone number JID.
+	BusinessOwner types.JID
+	Title         string
+	Description   string
+	// The cover image of the catalog as JPEG data, e.g. the business profile picture. Optional.
+	Image []byte
+
+	// The message text. Defaults to the catalog link from [CatalogLink].
+	Body        string
+	Footer      string
+	ContextInfo *waE2E.ContextInfo
+}
+
+// BuildCatalogMessage builds a message that shares a whole catalog.
+func (cli *Client) BuildCatalogMessage(ctx context.Context, params CatalogMessageParams) (*waE2E.Message, error) {
+	owner := cli.getBusinessOwner(params.BusinessOwner)
+	snapshot := &waE2E.ProductMessage_CatalogSnapshot{
+		Title: proto.String(params.Title),
+	}
+	if params.Description != "" {
+		snapshot.Description = proto.String(params.Description)
+	}
+	if len(params.Image) > 0 {
+		var err error
+		snapshot.CatalogImage, err = cli.buildCatalogImage(ctx, params.Image, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	body := params.Body
+	if body == "" {
+		body = CatalogLink(owner)
+	}
+	msg := &waE2E.ProductMessage{
+		Catalog:          snapshot,
+		BusinessOwnerJID: proto.String(owner.String()),
+		Body:             proto.String(body),
+		ContextInfo:      params.ContextInfo,
+	}
+	if params.Footer != "" {
+		msg.Footer = proto.String(params.Footer)
+	}
+	return &waE2E.Message{ProductMessage: msg}, nil
+}
+
+// OrderMessageParams contains the parameters for [BuildOrderMessage].
+type OrderMessageParams struct {
+	// The order to send. Only the ID, products and price are used.
+	// Orders are created by the WhatsApp servers, so the order must already exist (see [Client.GetOrderDetails]).
+	Order *types.OrderDetails
+	// The token found in the original order message, required for the receiver to fetch the order details.
+	Token string
+	// The business that sells the products.
+	Seller types.JID
+
+	Title   string
+	Message string
+	// The status of the order. Defaults to INQUIRY.
+	Status waE2E.OrderMessage_OrderStatus
+	// A small JPEG thumbnail of the order, usually the image of the first product.
+	Thumbnail   []byte
+	ContextInfo *waE2E.ContextInfo
+}
+
+// BuildOrderMessage builds a message containing an order. The item count and total are taken from the order details.
+func BuildOrderMessage(params OrderMessageParams) (*waE2E.Message, error) {
+	if params.Order == nil || params.Order.ID == "" || params.Token == "" {
+		return nil, ErrOrderTokenMissing
+	}
+	itemCount := 0
+	for _, product := range params.Order.Products {
+		itemCount += max(product.Quantity, 1)
+	}
+	total, currency := getOrderTotal(params.Order)
+	status := params.Status
+	if status == 0 {
+		status = waE2E.OrderMessage_INQUIRY
+	}
+	msg := &waE2E.OrderMessage{
+		OrderID:           proto.String(params.Order.ID),
+		Thumbnail:         params.Thumbnail,
+		ItemCount:         proto.Int32(int32(itemCount)),
+		Status:            status.Enum(),
+		Surface:           waE2E.OrderMessage_CATALOG.Enum(),
+		SellerJID:         proto.String(params.Seller.ToNonAD().String()),
+		Token:             proto.String(params.Token),
+		TotalAmount1000:   proto.Int64(total),
+		TotalCurrencyCode: proto.String(currency),
+		MessageVersion:    proto.Int32(2),
+		ContextInfo:       params.ContextInfo,
+	}
+	if params.Title != "" {
+		msg.OrderTitle = proto.String(params.Title)
+	}
+	if params.Message != "" {
+		msg.Message = proto.String(params.Message)
+	}
+	return &waE2E.Message{OrderMessage: msg}, nil
+}
+
+// ParseProductMessage parses the product or catalog shared in a product message.
+func ParseProductMessage(msg *waE2E.ProductMessage) (*types.ProductMessageInfo, error) {
+	if msg.GetProduct() == nil && msg.GetCatalog() == nil {
+		return nil, ErrNoProductInfo
+	}
+	info := &types.ProductMessageInfo{
+		Body:   msg.GetBody(),
+		Footer: msg.GetFooter(),
+	}
+	if ownerStr := msg.GetBusinessOwnerJID(); ownerStr != "" {
+		owner, err := types.ParseJID(ownerStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse business owner JID: %w", err)
+		}
+		info.BusinessOwner = owner
+	}
+	if product := msg.GetProduct(); product != nil {
+		info.Product = &types.CatalogProduct{
+			ID:          product.GetProductID(),
+			RetailerID:  product.GetRetailerID(),
+			Name:        product.GetTitle(),
+			Description: product.GetDescription(),
+			URL:         product.GetURL(),
+			Price:       product.GetPriceAmount1000(),
+			Currency:    product.GetCurrencyCode(),
+		}
+		info.ProductImageCount = int(product.GetProductImageCount())
+	}
+	if catalog := msg.GetCatalog(); catalog != nil {
+		info.CatalogTitle = catalog.GetTitle()
+		info.CatalogDescription = catalog.GetDescription()
+	}
+	return info, nil
+}
+
+// GetOrderFromMessage fetches the details of the order in an order message using [Client.GetOrderDetails]
+// and returns them along with the line items and totals in a structured form.
+func (cli *Client) GetOrderFromMessage(ctx context.Context, msg *waE2E.OrderMessage) (*types.Order, error) {
+	if msg.GetOrderID() == "" || msg.GetToken() == "" {
+		return nil, ErrOrderTokenMissing
+	}
+	details, err := cli.GetOrderDetails(ctx, msg.GetOrderID(), msg.GetToken())
+	if err != nil {
+		return nil, err
+	}
+	order := &types.Order{
+		ID:          details.ID,
+		Title:       msg.GetOrderTitle(),
+		Message:     msg.GetMessage(),
+		CatalogID:   details.CatalogID,
+		CreatedAt:   details.CreatedAt,
+		Items:       make([]types.OrderLineItem, len(details.Products)),
+		Subtotal:    details.Price.Subtotal,
+		PriceStatus: details.Price.PriceStatus,
+	}
+	if msg.Status != nil {
+		order.Status = strings.ToLower(msg.GetStatus().String())
+	}
+	if sellerStr := msg.GetSellerJID(); sellerStr != "" {
+		order.Seller, err = types.ParseJID(sellerStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse seller JID: %w", err)
+		}
+	}
+	var lineSum int64
+	for i, product := range details.Products {
+		quantity := max(product.Quantity, 1)
+		order.Items[i] = types.OrderLineItem{
+			ProductID:         product.ID,
+			Name:              product.Name,
+			ImageURL:          product.ImageURL,
+			VariantProperties: product.VariantInfo.Properties,
+			Quantity:          quantity,
+			UnitPrice:         product.Price,
+			LineTotal:         product.Price * int64(quantity),
+			Currency:          product.Currency,
+		}
+		lineSum += order.Items[i].LineTotal
+	}
+	if order.Subtotal == 0 {
+		order.Subtotal = lineSum
+	}
+	order.Total, order.Currency = getOrderTotal(details)
+	if order.Total == 0 {
+		order.Total = msg.GetTotalAmount1000()
+	}
+	if order.Currency == "" {
+		order.Currency = msg.GetTotalCurrencyCode()
+	}
+	return order, nil
+}
+
+func getOrderTotal(details *types.OrderDetails) (int64, string) {
+	total, currency := details.Price.Total, details.Price.Currency
+	if total == 0 {
+		total = details.Price.Subtotal
+	}
+	if total == 0 {
+		for _, product := range details.Products {
+			total += product.Price * int64(max(product.Quantity, 1))
+		}
+	}
+	if currency == "" && len(details.Products) > 0 {
+		currency = details.Products[0].Currency
+	}
+	return total, currency
+}
+
+func (cli *Client) getBusinessOwner(owner types.JID) types.JID {
+	if owner.IsEmpty() {
+		return cli.getOwnID().ToNonAD()
+	}
+	return owner.ToNonAD()
+}
+
+func (cli *Client) buildCatalogImage(ctx context.Context, data []byte, imageURLs []string) (*waE2E.ImageMessage, error) {
+	if len(data) == 0 {
+		if len(imageURLs) == 0 {
+			return nil, ErrProductImageMissing
+		}
+		var err error
+		data, err = cli.downloadMedia(ctx, imageURLs[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to download product image: %w", err)
+		}
+	}
+	msg, err := cli.BuildMediaMessage(ctx, MediaMessageParams{Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload image: %w", err)
+	} else if msg.ImageMessage == nil {
+		return nil, ErrUnsupportedImageFormat
+	}
+	return msg.ImageMessage, nil
+}
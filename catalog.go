@@ -0,0 +1,328 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// productImageUploadPath is the upload path for product images, which are uploaded without encryption.
+const productImageUploadPath = "product/image"
+
+const (
+	// DefaultCatalogPageSize is the default number of products fetched by [Client.GetCatalog].
+	DefaultCatalogPageSize = 10
+	// DefaultCatalogCollectionLimit is the default number of collections fetched by [Client.GetCatalogCollections].
+	DefaultCatalogCollectionLimit = 50
+	// DefaultCatalogCollectionItemLimit is the default number of products fetched per collection by [Client.GetCatalogCollections].
+	DefaultCatalogCollectionItemLimit = 10
+)
+
+const catalogImageSize = "100"
+
+var ErrProductNameRequired = errors.New("product name is required")
+
+// CatalogProductParams contains the fields of a product for [Client.CreateCatalogProduct] and [Client.EditCatalogProduct].
+type CatalogProductParams struct {
+	Name        string
+	Description string
+	// Your own identifier for the product, e.g. the SKU in your inventory system.
+	RetailerID string
+	URL        string
+	// The price in thousandths of the currency unit, e.g. 12500 means 12.50. Zero means the price isn't shown.
+	Price int64
+	// The ISO 4217 currency code. Required if Price is set.
+	Currency string
+	IsHidden bool
+	// The two-letter code of the country where the product was made, required in some countries.
+	OriginCountryCode string
+
+	// Images to upload for the product, as JPEG data. The uploaded images are added after ImageURLs.
+	Images [][]byte
+	// URLs of already uploaded images, e.g. from [Client.UploadProductImage] or the ImageURLs of an existing product.
+	ImageURLs []string
+}
+
+// UploadProductImage uploads a JPEG image for use in catalog products and returns its URL.
+//
+// Usually you don't need to call this directly, use the Images field in [CatalogProductParams] instead.
+func (cli *Client) UploadProductImage(ctx context.Context, data []byte) (string, error) {
+	var resp UploadResponse
+	hash := sha256.Sum256(data)
+	err := cli.uploadToPath(ctx, bytes.NewReader(data), uint64(len(data)), hash[:], productImageUploadPath, &resp)
+	if err != nil {
+		return "", err
+	} else if resp.URL == "" {
+		return "", errors.New("upload response didn't contain an image URL")
+	}
+	return resp.URL, nil
+}
+
+// GetCatalog gets one page of the products in the catalog of the given business.
+//
+// The limit defaults to DefaultCatalogPageSize. To get the next page, pass the NextCursor of the previous page.
+// Hidden products are only included when fetching your own catalog.
+func (cli *Client) GetCatalog(ctx context.Context, jid types.JID, limit int, cursor string) (*types.CatalogPage, error) {
+	if limit <= 0 {
+		limit = DefaultCatalogPageSize
+	}
+	content := []waBinary.Node{
+		{Tag: "limit", Content: []byte(strconv.Itoa(limit))},
+		{Tag: "width", Content: []byte(catalogImageSize)},
+		{Tag: "height", Content: []byte(catalogImageSize)},
+	}
+	if cursor != "" {
+		content = append(content, waBinary.Node{Tag: "after", Content: []byte(cursor)})
+	}
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "w:biz:catalog",
+		Type:      iqGet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "product_catalog",
+			Attrs: waBinary.Attrs{
+				"jid":               jid.ToNonAD(),
+				"allow_shop_source": "true",
+			},
+			Content: content,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	catalogNode, ok := resp.GetOptionalChildByTag("product_catalog")
+	if !ok {
+		return nil, &ElementMissingError{Tag: "product_catalog", In: "response to catalog query"}
+	}
+	page := &types.CatalogPage{
+		Products: parseCatalogProducts(catalogNode),
+	}
+	if pagingNode, ok := catalogNode.GetOptionalChildByTag("paging"); ok {
+		page.NextCursor = getStringChild(pagingNode, "after")
+	}
+	return page, nil
+}
+
+// GetCatalogCollections gets the product collections of the given business.
+//
+// The limits default to DefaultCatalogCollectionLimit and DefaultCatalogCollectionItemLimit.
+func (cli *Client) GetCatalogCollections(ctx context.Context, jid types.JID, collectionLimit, itemLimit int) ([]*types.CatalogCollection, error) {
+	if collectionLimit <= 0 {
+		collectionLimit = DefaultCatalogCollectionLimit
+	}
+	if itemLimit <= 0 {
+		itemLimit = DefaultCatalogCollectionItemLimit
+	}
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "w:biz:catalog",
+		Type:      iqGet,
+		SMaxID:    "35",
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag:   "collections",
+			Attrs: waBinary.Attrs{"biz_jid": jid.ToNonAD()},
+			Content: []waBinary.Node{
+				{Tag: "collection_limit", Content: []byte(strconv.Itoa(collectionLimit))},
+				{Tag: "item_limit", Content: []byte(strconv.Itoa(itemLimit))},
+				{Tag: "width", Content: []byte(catalogImageSize)},
+				{Tag: "height", Content: []byte(catalogImageSize)},
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	collectionsNode, ok := resp.GetOptionalChildByTag("collections")
+	if !ok {
+		return nil, &ElementMissingError{Tag: "collections", In: "response to catalog collections query"}
+	}
+	collectionNodes := collectionsNode.GetChildrenByTag("collection")
+	collections := make([]*types.CatalogCollection, len(collectionNodes))
+	for i, node := range collectionNodes {
+		collections[i] = &types.CatalogCollection{
+			ID:       getStringChild(node, "id"),
+			Name:     getStringChild(node, "name"),
+			Products: parseCatalogProducts(node),
+		}
+		if statusNode, ok := node.GetOptionalChildByTag("status_info"); ok {
+			collections[i].ReviewStatus = getStringChild(statusNode, "status")
+		}
+	}
+	return collections, nil
+}
+
+// CreateCatalogProduct adds a new product to your own catalog.
+func (cli *Client) CreateCatalogProduct(ctx context.Context, params CatalogProductParams) (*types.CatalogProduct, error) {
+	productNode, err := cli.buildCatalogProductNode(ctx, "", params)
+	if err != nil {
+		return nil, err
+	}
+	return cli.sendCatalogProductMutation(ctx, "product_catalog_add", productNode)
+}
+
+// EditCatalogProduct replaces the details of a product in your own catalog.
+//
+// All fields are replaced, so the params should contain the full product info, not just the changed fields.
+// To keep the existing images, pass the ImageURLs of the product from [Client.GetCatalog].
+// Products can be hidden or shown by changing the IsHidden field.
+func (cli *Client) EditCatalogProduct(ctx context.Context, productID string, params CatalogProductParams) (*types.CatalogProduct, error) {
+	productNode, err := cli.buildCatalogProductNode(ctx, productID, params)
+	if err != nil {
+		return nil, err
+	}
+	return cli.sendCatalogProductMutation(ctx, "product_catalog_edit", productNode)
+}
+
+func (cli *Client) sendCatalogProductMutation(ctx context.Context, tag string, productNode waBinary.Node) (*types.CatalogProduct, error) {
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "w:biz:catalog",
+		Type:      iqSet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag:   tag,
+			Attrs: waBinary.Attrs{"v": "1"},
+			Content: []waBinary.Node{
+				productNode,
+				{Tag: "width", Content: []byte(catalogImageSize)},
+				{Tag: "height", Content: []byte(catalogImageSize)},
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	respNode, ok := resp.GetOptionalChildByTag(tag, "product")
+	if !ok {
+		return nil, &ElementMissingError{Tag: "product", In: "response to " + tag}
+	}
+	return parseCatalogProduct(respNode), nil
+}
+
+// DeleteCatalogProducts deletes products from your own catalog and returns the number of deleted products.
+func (cli *Client) DeleteCatalogProducts(ctx context.Context, productIDs ...string) (int, error) {
+	productNodes := make([]waBinary.Node, len(productIDs))
+	for i, id := range productIDs {
+		productNodes[i] = waBinary.Node{
+			Tag:     "product",
+			Content: []waBinary.Node{{Tag: "id", Content: []byte(id)}},
+		}
+	}
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "w:biz:catalog",
+		Type:      iqSet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag:     "product_catalog_delete",
+			Attrs:   waBinary.Attrs{"v": "1"},
+			Content: productNodes,
+		}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	deleteNode, ok := resp.GetOptionalChildByTag("product_catalog_delete")
+	if !ok {
+		return 0, &ElementMissingError{Tag: "product_catalog_delete", In: "response to product delete request"}
+	}
+	return deleteNode.AttrGetter().OptionalInt("deleted_count"), nil
+}
+
+func (cli *Client) buildCatalogProductNode(ctx context.Context, productID string, params CatalogProductParams) (waBinary.Node, error) {
+	if params.Name == "" {
+		return waBinary.Node{}, ErrProductNameRequired
+	}
+	imageURLs := params.ImageURLs
+	for i, image := range params.Images {
+		url, err := cli.UploadProductImage(ctx, image)
+		if err != nil {
+			return waBinary.Node{}, fmt.Errorf("failed to upload image #%d: %w", i+1, err)
+		}
+		imageURLs = append(imageURLs, url)
+	}
+	var content []waBinary.Node
+	addText := func(tag, value string) {
+		if value != "" {
+			content = append(content, waBinary.Node{Tag: tag, Content: []byte(value)})
+		}
+	}
+	addText("id", productID)
+	addText("name", params.Name)
+	addText("description", params.Description)
+	addText("retailer_id", params.RetailerID)
+	addText("url", params.URL)
+	if len(imageURLs) > 0 {
+		images := make([]waBinary.Node, len(imageURLs))
+		for i, url := range imageURLs {
+			images[i] = waBinary.Node{
+				Tag:     "image",
+				Content: []waBinary.Node{{Tag: "url", Content: []byte(url)}},
+			}
+		}
+		content = append(content, waBinary.Node{Tag: "media", Content: images})
+	}
+	if params.Price > 0 {
+		addText("price", strconv.FormatInt(params.Price, 10))
+		addText("currency", params.Currency)
+	}
+	if params.OriginCountryCode != "" {
+		content = append(content, waBinary.Node{
+			Tag:     "compliance_info",
+			Content: []waBinary.Node{{Tag: "country_code_origin", Content: []byte(params.OriginCountryCode)}},
+		})
+	}
+	return waBinary.Node{
+		Tag:     "product",
+		Attrs:   waBinary.Attrs{"is_hidden": strconv.FormatBool(params.IsHidden)},
+		Content: content,
+	}, nil
+}
+
+func parseCatalogProducts(node waBinary.Node) []*types.CatalogProduct {
+	productNodes := node.GetChildrenByTag("product")
+	products := make([]*types.CatalogProduct, len(productNodes))
+	for i, productNode := range productNodes {
+		products[i] = parseCatalogProduct(productNode)
+	}
+	return products
+}
+
+func parseCatalogProduct(node waBinary.Node) *types.CatalogProduct {
+	product := &types.CatalogProduct{
+		ID:          getStringChild(node, "id"),
+		RetailerID:  getStringChild(node, "retailer_id"),
+		Name:        getStringChild(node, "name"),
+		Description: getStringChild(node, "description"),
+		URL:         getStringChild(node, "url"),
+		Currency:    getStringChild(node, "currency"),
+		IsHidden:    node.AttrGetter().OptionalBool("is_hidden"),
+	}
+	product.Price, _ = strconv.ParseInt(getStringChild(node, "price"), 10, 64)
+	if mediaNode, ok := node.GetOptionalChildByTag("media"); ok {
+		for _, image := range mediaNode.GetChildrenByTag("image") {
+			if url := getStringChild(image, "original_image_url"); url != "" {
+				product.ImageURLs = append(product.ImageURLs, url)
+			} else if url = getStringChild(image, "request_image_url"); url != "" {
+				product.ImageURLs = append(product.ImageURLs, url)
+			}
+		}
+	}
+	if statusNode, ok := node.GetOptionalChildByTag("status_info"); ok {
+		product.ReviewStatus = getStringChild(statusNode, "status")
+	}
+	if complianceNode, ok := node.GetOptionalChildByTag("compliance_info"); ok {
+		product.OriginCountryCode = getStringChild(complianceNode, "country_code_origin")
+	}
+	return product
+}
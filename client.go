@@ -89,12 +89,20 @@ type Client struct {
 	DisableLoginAutoReconnect bool
 
 	sendActiveReceipts atomic.Uint32
+	presenceAvailable  atomic.Bool
 
 	// EmitAppStateEventsOnFullSync can be set to true if you want to get app state events emitted
 	// even when re-syncing the whole state.
 	EmitAppStateEventsOnFullSync bool
 	AppStateDebugLogs            bool
 
+	// EmitAlbumEvents can be set to true to get events.Album emitted after all items of an incoming album are received.
+	// AlbumTimeout is the maximum time to wait for all items before emitting the event anyway (defaults to DefaultAlbumTimeout).
+	EmitAlbumEvents   bool
+	AlbumTimeout      time.Duration
+	pendingAlbums     map[albumKey]*pendingAlbum
+	pendingAlbumsLock sync.Mutex
+
 	AutomaticMessageRerequestFromPhone bool
 	pendingPhoneRerequests             map[types.MessageID]context.CancelFunc
 	pendingPhoneRerequestsLock         sync.RWMutex
@@ -106,12 +114,14 @@ type Client struct {
 	historySyncHandlerStarted       atomic.Bool
 	ManualHistorySyncDownload       bool
 	DisableManualHistorySyncReceipt bool
+	AutomaticGroupHistoryDownload   bool
 
 	uploadPreKeysLock sync.Mutex
 	lastPreKeyUpload  time.Time
 
 	mediaConnCache *MediaConn
 	mediaConnLock  sync.Mutex
+	mediaHosts     *mediaHostTracker
 
 	responseWaiters     map[string]chan<- *waBinary.Node
 	responseWaitersLock sync.Mutex
@@ -140,8 +150,37 @@ type Client struct {
 
 	privacySettingsCache atomic.Value
 
-	groupCache           map[types.JID]*groupMetaCache
-	groupCacheLock       sync.Mutex
+	groupCache     *groupMetaCache
+	groupCacheLock sync.Mutex
+	// Incremented whenever a group is invalidated, so that stale store reads aren't put back in the cache.
+	groupCacheGeneration uint64
+	// The maximum number of groups whose metadata (members and addressing mode) is kept in memory.
+	// Defaults to DefaultGroupCacheSize, set to a negative value to disable the limit.
+	//
+	// If Store.Groups is set (which is opt-in), groups that don't fit are still kept there and loaded when needed.
+	// Stored metadata is kept up to date using group change notifications, and is deleted when the group is deleted,
+	// the announce setting changes, you leave the group, a participant change notification doesn't match the stored
+	// participant version, or the server reports a different participant list hash when sending a message.
+	// [Client.InvalidateGroupCache] can be used to delete it manually.
+	GroupCacheSize int
+
+	typingSessions     map[types.JID]*TypingSession
+	typingSessionsLock sync.Mutex
+
+	contactDiscoveryLock      sync.Mutex
+	lastContactDiscoveryQuery time.Time
+
+	trackedPresences map[types.JID]struct{}
+	presenceCache    map[types.JID]types.PresenceInfo
+	chatTyping       map[types.JID]map[types.JID]types.TypingUser
+	presenceLock     sync.Mutex
+	// How long a composing chat presence is considered valid if no paused state or message is received.
+	// Defaults to DefaultChatPresenceTimeout.
+	ChatPresenceTimeout time.Duration
+
+	textStatusCache     map[types.JID]*types.TextStatus
+	textStatusCacheLock sync.Mutex
+
 	userDevicesCache     map[types.JID]deviceCache
 	userDevicesCacheLock sync.Mutex
 
@@ -181,6 +220,9 @@ type Client struct {
 
 	// Should SubscribePresence return an error if no privacy token is stored for the user?
 	ErrorOnSubscribePresenceWithoutToken bool
+	// Should users tracked with TrackPresence be resubscribed after reconnecting even if no privacy token is stored for them?
+	// By default, they're skipped until a token is received.
+	ResubscribePresenceWithoutToken bool
 
 	SendReportingTokens bool
 
@@ -200,6 +242,10 @@ type Client struct {
 	websocketHTTP *http.Client
 	preLoginHTTP  *http.Client
 
+	mediaIPFallbackTransport *http.Transport
+	mediaIPFallbackBase      *http.Transport
+	mediaIPFallbackLock      sync.Mutex
+
 	// This field changes the client to act like a Messenger client instead of a WhatsApp one.
 	//
 	// Note that you cannot use a Messenger account just by setting this field, you must use a
@@ -212,12 +258,6 @@ type Client struct {
 	WebSocketHeaders http.Header
 }
 
-type groupMetaCache struct {
-	AddressingMode             types.AddressingMode
-	CommunityAnnouncementGroup bool
-	Members                    []types.JID
-}
-
 type MessengerConfig struct {
 	UserAgent    string
 	BaseURL      string
@@ -267,14 +307,20 @@ func NewClient(deviceStore *store.Device, log waLog.Logger) *Client {
 		appStateProc:       appstate.NewProcessor(deviceStore, log.Sub("AppState")),
 		socketWait:         make(chan struct{}),
 		expectedDisconnect: exsync.NewEvent(),
+		mediaHosts:         newMediaHostTracker(),
 
 		incomingRetryRequestCounter: make(map[incomingRetryKey]int),
 
 		historySyncNotifications: make(chan *waE2E.HistorySyncNotification, 32),
 
 		tcTokenSenderTS:  make(map[types.JID]time.Time),
-		groupCache:       make(map[types.JID]*groupMetaCache),
+		groupCache:       newGroupMetaCache(),
 		userDevicesCache: make(map[types.JID]deviceCache),
+		textStatusCache:  make(map[types.JID]*types.TextStatus),
+		trackedPresences: make(map[types.JID]struct{}),
+		typingSessions:   make(map[types.JID]*TypingSession),
+		presenceCache:    make(map[types.JID]types.PresenceInfo),
+		chatTyping:       make(map[types.JID]map[types.JID]types.TypingUser),
 
 		recentMessagesMap:      make(map[recentMessageKey]RecentMessage, recentMessagesSize),
 		sessionRecreateHistory: make(map[types.JID]time.Time),
@@ -282,6 +328,7 @@ func NewClient(deviceStore *store.Device, log waLog.Logger) *Client {
 		appStateKeyRequests:    make(map[string]time.Time),
 
 		pendingPhoneRerequests: make(map[types.MessageID]context.CancelFunc),
+		pendingAlbums:          make(map[albumKey]*pendingAlbum),
 
 		EnableAutoReconnect: true,
 		AutoTrustIdentity:   true,
@@ -592,6 +639,7 @@ func (cli *Client) onDisconnect(ctx context.Context, ns *socket.NoiseSocket, rem
 	if cli.socket == ns {
 		cli.socket = nil
 		cli.clearResponseWaiters(xmlStreamEndNode)
+		cli.presenceAvailable.Store(false)
 		if !cli.isExpectedDisconnect() && (cli.forceAutoReconnect.Swap(false) || remote) {
 			cli.Log.Debugf("Emitting Disconnected event")
 			go cli.dispatchEvent(&events.Disconnected{})
@@ -703,6 +751,8 @@ func (cli *Client) unlockedDisconnect() {
 		cli.socket.Stop(true, false)
 		cli.socket = nil
 		cli.clearResponseWaiters(xmlStreamEndNode)
+		// The server forgets the presence when the connection is closed
+		cli.presenceAvailable.Store(false)
 	}
 }
 
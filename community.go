@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+
+	"go.mau.fi/util/jsontime"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+var ErrNoAnnouncementGroup = errors.New("community doesn't have an announcement group")
+
+// DeactivateCommunity deactivates a community you own.
+//
+// All groups in the community are unlinked and the announcement group is removed. The groups themselves are not deleted.
+func (cli *Client) DeactivateCommunity(ctx context.Context, community types.JID) error {
+	_, err := cli.sendGroupIQ(ctx, iqSet, community, waBinary.Node{Tag: "delete_parent"})
+	return err
+}
+
+type respUpdateCommunityOwner struct {
+	Update struct {
+		GroupID types.JID `json:"group_id"`
+	} `json:"xwa2_group_update_users_role"`
+}
+
+// UpdateCommunityOwner transfers the ownership of a community to another member.
+//
+// The new owner must already be a member of the community. You will remain an admin after the transfer.
+func (cli *Client) UpdateCommunityOwner(ctx context.Context, community, newOwner types.JID) error {
+	_, err := MexQuery[respUpdateCommunityOwner](ctx, cli, "UpdateCommunityOwner", map[string]any{
+		"group_id": community.String(),
+		"role_updates": []map[string]any{{
+			"user_jid": newOwner.String(),
+			"new_role": types.GroupParticipantRoleSuperAdmin,
+		}},
+	})
+	return err
+}
+
+type respGetCommunityParticipantCount struct {
+	Participants struct {
+		TotalCount int `json:"total_count"`
+	} `json:"xwa2_group_query_linked_groups_participants"`
+}
+
+// GetCommunityParticipantCount gets the total number of unique participants in all groups of a community.
+//
+// Use [Client.GetLinkedGroupsParticipants] to get the full participant list.
+func (cli *Client) GetCommunityParticipantCount(ctx context.Context, community types.JID) (int, error) {
+	resp, err := MexQuery[respGetCommunityParticipantCount](ctx, cli, "QueryCommunityParticipantCount", map[string]any{
+		"group_id": community.String(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Participants.TotalCount, nil
+}
+
+type respGetSubGroupParticipantCounts struct {
+	Group struct {
+		SubGroups *struct {
+			Edges []*struct {
+				Node struct {
+					ID                     types.JID `json:"id"`
+					TotalParticipantsCount int       `json:"total_participants_count"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"sub_groups"`
+	} `json:"xwa2_group_query_by_id"`
+}
+
+// GetSubGroupParticipantCounts gets the number of participants in each group of a community.
+func (cli *Client) GetSubGroupParticipantCounts(ctx context.Context, community types.JID) (map[types.JID]int, error) {
+	resp, err := MexQuery[respGetSubGroupParticipantCounts](ctx, cli, "QuerySubgroupParticipantCount", map[string]any{
+		"group_id": community.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[types.JID]int)
+	if resp.Group.SubGroups != nil {
+		for _, edge := range resp.Group.SubGroups.Edges {
+			if edge != nil {
+				counts[edge.Node.ID] = edge.Node.TotalParticipantsCount
+			}
+		}
+	}
+	return counts, nil
+}
+
+type respGetSuggestedGroups struct {
+	Group struct {
+		Suggestions struct {
+			Edges []struct {
+				Node struct {
+					ID      types.JID `json:"id"`
+					Subject *struct {
+						Value string `json:"value"`
+					} `json:"subject"`
+					Description *struct {
+						Value string `json:"value"`
+					} `json:"description"`
+					Creator struct {
+						ID types.JID `json:"id"`
+					} `json:"creator"`
+					CreationTime           jsontime.UnixString `json:"creation_time"`
+					TotalParticipantsCount int                 `json:"total_participants_count"`
+					IsExistingGroup        bool                `json:"is_existing_group"`
+					HiddenGroup            bool                `json:"hidden_group"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"sub_group_suggestions"`
+	} `json:"xwa2_group_query_by_id"`
+}
+
+// GetSuggestedGroups gets the groups that community members have suggested to be added to the community.
+func (cli *Client) GetSuggestedGroups(ctx context.Context, community types.JID) ([]*types.SuggestedGroup, error) {
+	resp, err := MexQuery[respGetSuggestedGroups](ctx, cli, "QuerySuggestedGroups", map[string]any{
+		"group_id": community.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	suggestions := make([]*types.SuggestedGroup, len(resp.Group.Suggestions.Edges))
+	for i, edge := range resp.Group.Suggestions.Edges {
+		suggestions[i] = &types.SuggestedGroup{
+			JID:              edge.Node.ID,
+			Creator:          edge.Node.Creator.ID,
+			CreationTime:     edge.Node.CreationTime.Time,
+			ParticipantCount: edge.Node.TotalParticipantsCount,
+			IsExistingGroup:  edge.Node.IsExistingGroup,
+			IsHidden:         edge.Node.HiddenGroup,
+		}
+		if edge.Node.Subject != nil {
+			suggestions[i].Name = edge.Node.Subject.Value
+		}
+		if edge.Node.Description != nil {
+			suggestions[i].Description = edge.Node.Description.Value
+		}
+	}
+	return suggestions, nil
+}
+
+// GetCommunityAnnouncementGroup finds the announcement group of a community.
+//
+// The announcement group is created automatically with the community, and only community admins can send messages in it.
+func (cli *Client) GetCommunityAnnouncementGroup(ctx context.Context, community types.JID) (*types.GroupLinkTarget, error) {
+	subGroups, err := cli.GetSubGroups(ctx, community)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range subGroups {
+		if group.IsDefaultSubGroup {
+			return group, nil
+		}
+	}
+	return nil, ErrNoAnnouncementGroup
+}
+
+// SendCommunityAnnouncement sends a message to the announcement group of a community.
+// This is a shortcut for [Client.GetCommunityAnnouncementGroup] followed by [Client.SendMessage].
+func (cli *Client) SendCommunityAnnouncement(ctx context.Context, community types.JID, message *waE2E.Message, extra ...SendRequestExtra) (SendResponse, error) {
+	group, err := cli.GetCommunityAnnouncementGroup(ctx, community)
+	if err != nil {
+		return SendResponse{}, err
+	}
+	return cli.SendMessage(ctx, group.JID, message, extra...)
+}
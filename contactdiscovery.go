@@ -0,0 +1,305 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+)
+
+const (
+	// DefaultContactDiscoveryChunkSize is the default number of phone numbers checked in one usync query by [Client.DiscoverContacts].
+	DefaultContactDiscoveryChunkSize = 50
+	// DefaultContactDiscoveryDelay is the default minimum delay between usync queries sent by [Client.DiscoverContacts].
+	DefaultContactDiscoveryDelay = 5 * time.Second
+	// DefaultContactDiscoveryMaxRetries is the default number of times a rate-limited query is retried.
+	DefaultContactDiscoveryMaxRetries = 3
+	// DefaultContactDiscoveryCacheTTL is the default time for which registered phone numbers are cached.
+	DefaultContactDiscoveryCacheTTL = 7 * 24 * time.Hour
+	// DefaultContactDiscoveryNegativeCacheTTL is the default time for which unregistered phone numbers are cached.
+	DefaultContactDiscoveryNegativeCacheTTL = 24 * time.Hour
+)
+
+var (
+	ErrInvalidPhoneNumber = errors.New("invalid phone number")
+	// ErrContactDiscoveryBudgetExhausted is set in [ContactDiscoveryResult] for numbers that weren't checked
+	// because [ContactDiscoveryParams.QueryBudget] was reached.
+	ErrContactDiscoveryBudgetExhausted = errors.New("contact discovery query budget exhausted")
+	// ErrContactDiscoveryMissingResult is set in [ContactDiscoveryResult] for numbers that were queried,
+	// but weren't included in the server's response. Their registration status is unknown and they aren't cached.
+	ErrContactDiscoveryMissingResult = errors.New("phone number missing in contact discovery response")
+)
+
+// NormalizePhoneNumber converts a phone number in international format to plain digits without the `+` prefix.
+//
+// Common formatting characters (spaces, dashes, dots, slashes and parentheses) are removed and a `00` prefix
+// is treated as `+`. Numbers without a country code can't be normalized reliably, so local numbers
+// starting with a single `0` are rejected.
+func NormalizePhoneNumber(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	phone = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '-', '.', '/', '(', ')':
+			return -1
+		default:
+			return r
+		}
+	}, phone)
+	if strings.HasPrefix(phone, "+") {
+		phone = phone[1:]
+	} else if strings.HasPrefix(phone, "00") {
+		phone = phone[2:]
+	}
+	// E.164 numbers have at most 15 digits
+	if len(phone) < 7 || len(phone) > 15 || phone[0] == '0' {
+		return "", ErrInvalidPhoneNumber
+	}
+	for _, r := range phone {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+	return phone, nil
+}
+
+// ContactDiscoveryParams contains optional parameters for [Client.DiscoverContacts].
+type ContactDiscoveryParams struct {
+	// The number of phone numbers to check in one query. Defaults to DefaultContactDiscoveryChunkSize.
+	ChunkSize int
+	// The minimum delay between queries. The delay is shared by all DiscoverContacts calls on the same client.
+	// Defaults to DefaultContactDiscoveryDelay, set to a negative value to disable.
+	Delay time.Duration
+	// The number of times a rate-limited query is retried. The delay before each retry is doubled.
+	// Defaults to DefaultContactDiscoveryMaxRetries, set to a negative value to disable retries.
+	MaxRetries int
+	// The maximum number of queries sent by one call. Numbers that don't fit in the budget are returned with
+	// ErrContactDiscoveryBudgetExhausted and can be checked by calling DiscoverContacts again later.
+	// Zero means no limit.
+	QueryBudget int
+	// How long registered and unregistered numbers are cached in Store.ContactDiscovery. Default to
+	// DefaultContactDiscoveryCacheTTL and DefaultContactDiscoveryNegativeCacheTTL, set to a negative value
+	// to always check the numbers again.
+	CacheTTL         time.Duration
+	NegativeCacheTTL time.Duration
+}
+
+func (cdp *ContactDiscoveryParams) withDefaults() ContactDiscoveryParams {
+	var out ContactDiscoveryParams
+	if cdp != nil {
+		out = *cdp
+	}
+	if out.ChunkSize <= 0 {
+		out.ChunkSize = DefaultContactDiscoveryChunkSize
+	}
+	if out.Delay == 0 {
+		out.Delay = DefaultContactDiscoveryDelay
+	} else if out.Delay < 0 {
+		out.Delay = 0
+	}
+	if out.MaxRetries == 0 {
+		out.MaxRetries = DefaultContactDiscoveryMaxRetries
+	} else if out.MaxRetries < 0 {
+		out.MaxRetries = 0
+	}
+	if out.CacheTTL == 0 {
+		out.CacheTTL = DefaultContactDiscoveryCacheTTL
+	}
+	if out.NegativeCacheTTL == 0 {
+		out.NegativeCacheTTL = DefaultContactDiscoveryNegativeCacheTTL
+	}
+	return out
+}
+
+// ContactDiscoveryResult is the result of [Client.DiscoverContacts] for a single phone number.
+type ContactDiscoveryResult struct {
+	// The phone number that was passed to DiscoverContacts.
+	Input string
+	// The registration info. Query contains the normalized phone number with the `+` prefix.
+	types.IsOnWhatsAppResponse
+	// Whether the result was loaded from the cache. Cached results don't include the verified business name.
+	Cached bool
+	// ErrInvalidPhoneNumber, ErrContactDiscoveryBudgetExhausted, ErrContactDiscoveryMissingResult
+	// or the error returned by the query.
+	Err error
+}
+
+// DiscoverContacts checks if a large number of phone numbers are registered on WhatsApp.
+//
+// Unlike [Client.IsOnWhatsApp], the numbers are normalized and deduplicated, results (including negative ones)
+// are cached in Store.ContactDiscovery, and the remaining numbers are split into chunks that are sent as
+// separate queries with a delay in between. LID mappings returned by the server are stored like in IsOnWhatsApp.
+//
+// The returned list contains a result for each input number in the same order.
+// The error is only non-nil if the context was canceled.
+func (cli *Client) DiscoverContacts(ctx context.Context, phones []string, params *ContactDiscoveryParams) ([]ContactDiscoveryResult, error) {
+	return cli.doDiscoverContacts(ctx, phones, params.withDefaults(), cli.IsOnWhatsApp)
+}
+
+func (cli *Client) doDiscoverContacts(
+	ctx context.Context,
+	phones []string,
+	p ContactDiscoveryParams,
+	query func(ctx context.Context, phones []string) ([]types.IsOnWhatsAppResponse, error),
+) ([]ContactDiscoveryResult, error) {
+	results := make([]ContactDiscoveryResult, len(phones))
+	indexes := make(map[string][]int, len(phones))
+	uniquePhones := make([]string, 0, len(phones))
+	for i, input := range phones {
+		results[i].Input = input
+		phone, err := NormalizePhoneNumber(input)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Query = "+" + phone
+		results[i].PhoneNumber = types.NewJID(phone, types.DefaultUserServer)
+		if _, seen := indexes[phone]; !seen {
+			uniquePhones = append(uniquePhones, phone)
+		}
+		indexes[phone] = append(indexes[phone], i)
+	}
+	setResult := func(phone string, fn func(res *ContactDiscoveryResult)) {
+		for _, i := range indexes[phone] {
+			fn(&results[i])
+		}
+	}
+
+	now := time.Now()
+	if p.CacheTTL > 0 || p.NegativeCacheTTL > 0 {
+		err := cli.Store.ContactDiscovery.DeleteOldContactDiscoveryResults(ctx, now.Add(-max(p.CacheTTL, p.NegativeCacheTTL)))
+		if err != nil {
+			cli.Log.Warnf("Failed to delete old contact discovery results: %v", err)
+		}
+		cached, err := cli.Store.ContactDiscovery.GetContactDiscoveryResults(ctx, uniquePhones)
+		if err != nil {
+			cli.Log.Warnf("Failed to get cached contact discovery results: %v", err)
+		}
+		uniquePhones = slices.DeleteFunc(uniquePhones, func(phone string) bool {
+			entry, ok := cached[phone]
+			if !ok {
+				return false
+			}
+			ttl := p.NegativeCacheTTL
+			if entry.IsIn {
+				ttl = p.CacheTTL
+			}
+			if ttl <= 0 || now.Sub(entry.CheckedAt) > ttl {
+				return false
+			}
+			setResult(phone, func(res *ContactDiscoveryResult) {
+				res.IsIn = entry.IsIn
+				res.JID = entry.JID
+				res.Cached = true
+			})
+			return true
+		})
+	}
+
+	queries := 0
+	for chunk := range slices.Chunk(uniquePhones, p.ChunkSize) {
+		if p.QueryBudget > 0 && queries >= p.QueryBudget {
+			for _, phone := range chunk {
+				setResult(phone, func(res *ContactDiscoveryResult) {
+					res.Err = ErrContactDiscoveryBudgetExhausted
+				})
+			}
+			continue
+		}
+		queries++
+		resp, err := cli.discoverContactChunk(ctx, chunk, p, query)
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		} else if err != nil && resp == nil {
+			for _, phone := range chunk {
+				setResult(phone, func(res *ContactDiscoveryResult) {
+					res.Err = err
+				})
+			}
+			continue
+		} else if err != nil {
+			// The query succeeded, but storing LID mappings failed
+			cli.Log.Warnf("Post-processing of contact discovery query failed: %v", err)
+		}
+		checkedAt := time.Now()
+		cacheEntries := make([]store.ContactDiscoveryEntry, 0, len(resp))
+		answered := make(map[string]struct{}, len(resp))
+		for _, info := range resp {
+			phone := strings.TrimPrefix(info.Query, "+")
+			if _, ok := indexes[phone]; !ok {
+				continue
+			}
+			answered[phone] = struct{}{}
+			setResult(phone, func(res *ContactDiscoveryResult) {
+				res.IsOnWhatsAppResponse = info
+				if res.PhoneNumber.IsEmpty() {
+					res.PhoneNumber = types.NewJID(phone, types.DefaultUserServer)
+				}
+			})
+			cacheEntries = append(cacheEntries, store.ContactDiscoveryEntry{
+				PhoneNumber: phone,
+				IsIn:        info.IsIn,
+				JID:         info.JID,
+				CheckedAt:   checkedAt,
+			})
+		}
+		for _, phone := range chunk {
+			if _, ok := answered[phone]; !ok {
+				setResult(phone, func(res *ContactDiscoveryResult) {
+					res.Err = ErrContactDiscoveryMissingResult
+				})
+			}
+		}
+		if p.CacheTTL > 0 || p.NegativeCacheTTL > 0 {
+			err = cli.Store.ContactDiscovery.PutContactDiscoveryResults(ctx, cacheEntries)
+			if err != nil {
+				cli.Log.Warnf("Failed to cache contact discovery results: %v", err)
+			}
+		}
+	}
+	return results, nil
+}
+
+func (cli *Client) discoverContactChunk(
+	ctx context.Context,
+	chunk []string,
+	params ContactDiscoveryParams,
+	query func(ctx context.Context, phones []string) ([]types.IsOnWhatsAppResponse, error),
+) ([]types.IsOnWhatsAppResponse, error) {
+	queryPhones := make([]string, len(chunk))
+	for i, phone := range chunk {
+		queryPhones[i] = "+" + phone
+	}
+	// Hold the lock for the whole chunk, so concurrent calls share the pacing
+	cli.contactDiscoveryLock.Lock()
+	defer cli.contactDiscoveryLock.Unlock()
+	for attempt := 0; ; attempt++ {
+		delay := params.Delay
+		if attempt > 0 {
+			delay = max(params.Delay, time.Second) << attempt
+		}
+		wait := time.Until(cli.lastContactDiscoveryQuery.Add(delay))
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+		resp, err := query(ctx, queryPhones)
+		cli.lastContactDiscoveryQuery = time.Now()
+		if !errors.Is(err, ErrIQRateOverLimit) || attempt >= params.MaxRetries {
+			return resp, err
+		}
+		cli.Log.Debugf("Contact discovery query was rate limited, retrying (attempt %d/%d)", attempt+1, params.MaxRetries)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+	waLog "go.mau.fi/whatsmeow/util/log"
+)
+
+type memoryContactDiscoveryStore struct {
+	entries map[string]store.ContactDiscoveryEntry
+}
+
+func (m *memoryContactDiscoveryStore) PutContactDiscoveryResults(ctx context.Context, entries []store.ContactDiscoveryEntry) error {
+	for _, entry := range entries {
+		m.entries[entry.PhoneNumber] = entry
+	}
+	return nil
+}
+
+func (m *memoryContactDiscoveryStore) GetContactDiscoveryResults(ctx context.Context, phones []string) (map[string]store.ContactDiscoveryEntry, error) {
+	out := make(map[string]store.ContactDiscoveryEntry)
+	for _, phone := range phones {
+		if entry, ok := m.entries[phone]; ok {
+			out[phone] = entry
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryContactDiscoveryStore) DeleteOldContactDiscoveryResults(ctx context.Context, olderThan time.Time) error {
+	for phone, entry := range m.entries {
+		if entry.CheckedAt.Before(olderThan) {
+			delete(m.entries, phone)
+		}
+	}
+	return nil
+}
+
+func newContactDiscoveryTestClient() (*Client, *memoryContactDiscoveryStore) {
+	cds := &memoryContactDiscoveryStore{entries: make(map[string]store.ContactDiscoveryEntry)}
+	return &Client{Log: waLog.Noop, Store: &store.Device{ContactDiscovery: cds}}, cds
+}
+
+// fakeIsOnWhatsApp marks numbers ending in an even digit as registered.
+func fakeIsOnWhatsApp(chunks *[][]string) func(ctx context.Context, phones []string) ([]types.IsOnWhatsAppResponse, error) {
+	return func(ctx context.Context, phones []string) ([]types.IsOnWhatsAppResponse, error) {
+		*chunks = append(*chunks, phones)
+		resp := make([]types.IsOnWhatsAppResponse, len(phones))
+		for i, phone := range phones {
+			resp[i].Query = phone
+			resp[i].IsIn = (phone[len(phone)-1]-'0')%2 == 0
+			if resp[i].IsIn {
+				resp[i].JID = types.NewJID(strings.TrimPrefix(phone, "+")+"0", types.HiddenUserServer)
+			}
+		}
+		return resp, nil
+	}
+}
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		err      error
+	}{
+		{"+1 (555) 123-4567", "15551234567", nil},
+		{"0044 20.7946.0000", "442079460000", nil},
+		{" +358/40 123 4567 ", "358401234567", nil},
+		{"15551234567", "15551234567", nil},
+		{"040 1234567", "", ErrInvalidPhoneNumber},
+		{"+1 555", "", ErrInvalidPhoneNumber},
+		{"+1234567890123456", "", ErrInvalidPhoneNumber},
+		{"+1 555 CALL NOW", "", ErrInvalidPhoneNumber},
+		{"", "", ErrInvalidPhoneNumber},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			phone, err := NormalizePhoneNumber(test.input)
+			if phone != test.expected || !errors.Is(err, test.err) {
+				t.Errorf("expected %q/%v, got %q/%v", test.expected, test.err, phone, err)
+			}
+		})
+	}
+}
+
+func TestContactDiscoveryParams_WithDefaults(t *testing.T) {
+	p := (*ContactDiscoveryParams)(nil).withDefaults()
+	if p.ChunkSize != DefaultContactDiscoveryChunkSize || p.Delay != DefaultContactDiscoveryDelay ||
+		p.MaxRetries != DefaultContactDiscoveryMaxRetries || p.CacheTTL != DefaultContactDiscoveryCacheTTL ||
+		p.NegativeCacheTTL != DefaultContactDiscoveryNegativeCacheTTL {
+		t.Errorf("unexpected defaults %+v", p)
+	}
+	p = (&ContactDiscoveryParams{ChunkSize: 5, Delay: -1, MaxRetries: -1, CacheTTL: -1, NegativeCacheTTL: time.Hour}).withDefaults()
+	if p.ChunkSize != 5 || p.Delay != 0 || p.MaxRetries != 0 || p.CacheTTL != -1 || p.NegativeCacheTTL != time.Hour {
+		t.Errorf("unexpected params %+v", p)
+	}
+}
+
+func TestDoDiscoverContacts_Chunking(t *testing.T) {
+	cli, cds := newContactDiscoveryTestClient()
+	phones := []string{
+		"+1 555 000 0001", "+15550000002", "invalid", "+15550000003", "0015550000002",
+		"+15550000004", "+15550000005", "+15550000006", "+15550000007",
+	}
+	var chunks [][]string
+	queryFn := fakeIsOnWhatsApp(&chunks)
+	p := (&ContactDiscoveryParams{ChunkSize: 3, Delay: -1, MaxRetries: -1}).withDefaults()
+	results, err := cli.doDiscoverContacts(context.Background(), phones, p, func(ctx context.Context, phones []string) ([]types.IsOnWhatsAppResponse, error) {
+		if len(chunks) == 1 {
+			chunks = append(chunks, phones)
+			return nil, ErrIQRateOverLimit
+		}
+		return queryFn(ctx, phones)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedChunks := [][]string{
+		{"+15550000001", "+15550000002", "+15550000003"},
+		{"+15550000004", "+15550000005", "+15550000006"},
+		{"+15550000007"},
+	}
+	if !slices.EqualFunc(chunks, expectedChunks, slices.Equal) {
+		t.Fatalf("expected chunks %v, got %v", expectedChunks, chunks)
+	}
+	if len(results) != len(phones) {
+		t.Fatalf("expected %d results, got %d", len(phones), len(results))
+	}
+	for i, res := range results {
+		if res.Input != phones[i] {
+			t.Errorf("result %d: expected input %q, got %q", i, phones[i], res.Input)
+		} else if res.Cached {
+			t.Errorf("result %d: unexpectedly cached", i)
+		}
+	}
+	if !errors.Is(results[2].Err, ErrInvalidPhoneNumber) {
+		t.Errorf("expected ErrInvalidPhoneNumber for invalid input, got %v", results[2].Err)
+	}
+	if results[0].Err != nil || results[0].IsIn || results[0].PhoneNumber != types.NewJID("15550000001", types.DefaultUserServer) {
+		t.Errorf("unexpected result for unregistered number: %+v", results[0])
+	}
+	expectedJID := types.NewJID("155500000020", types.HiddenUserServer)
+	for _, i := range []int{1, 4} {
+		if results[i].Err != nil || !results[i].IsIn || results[i].JID != expectedJID || results[i].Query != "+15550000002" {
+			t.Errorf("result %d: unexpected result for registered number: %+v", i, results[i])
+		}
+	}
+	for _, i := range []int{5, 6, 7} {
+		if !errors.Is(results[i].Err, ErrIQRateOverLimit) || results[i].IsIn {
+			t.Errorf("result %d: unexpected result for failed chunk: %+v", i, results[i])
+		}
+	}
+	if len(cds.entries) != 4 {
+		t.Errorf("expected 4 cached entries, got %d", len(cds.entries))
+	} else if _, ok := cds.entries["15550000005"]; ok {
+		t.Error("number from failed chunk was cached")
+	}
+}
+
+func TestDoDiscoverContacts_QueryBudget(t *testing.T) {
+	cli, _ := newContactDiscoveryTestClient()
+	phones := []string{"+15550000001", "+15550000002", "+15550000003", "+15550000004", "+15550000005"}
+	var chunks [][]string
+	p := (&ContactDiscoveryParams{ChunkSize: 2, Delay: -1, QueryBudget: 1, CacheTTL: -1, NegativeCacheTTL: -1}).withDefaults()
+	results, err := cli.doDiscoverContacts(context.Background(), phones, p, fakeIsOnWhatsApp(&chunks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 query, got %d", len(chunks))
+	}
+	for i, res := range results {
+		if i < 2 && res.Err != nil {
+			t.Errorf("result %d: unexpected error %v", i, res.Err)
+		} else if i >= 2 && !errors.Is(res.Err, ErrContactDiscoveryBudgetExhausted) {
+			t.Errorf("result %d: expected ErrContactDiscoveryBudgetExhausted, got %v", i, res.Err)
+		}
+	}
+}
+
+func TestDoDiscoverContacts_Cache(t *testing.T) {
+	cli, cds := newContactDiscoveryTestClient()
+	now := time.Now()
+	lid := types.NewJID("987654321", types.HiddenUserServer)
+	cds.entries["15550000001"] = store.ContactDiscoveryEntry{PhoneNumber: "15550000001", IsIn: true, JID: lid, CheckedAt: now.Add(-2 * time.Hour)}
+	cds.entries["15550000002"] = store.ContactDiscoveryEntry{PhoneNumber: "15550000002", CheckedAt: now.Add(-30 * time.Minute)}
+	cds.entries["15550000003"] = store.ContactDiscoveryEntry{PhoneNumber: "15550000003", CheckedAt: now.Add(-2 * time.Hour)}
+	cds.entries["15550000004"] = store.ContactDiscoveryEntry{PhoneNumber: "15550000004", IsIn: true, CheckedAt: now.Add(-48 * time.Hour)}
+	phones := []string{"+15550000001", "+15550000002", "+15550000003", "+15550000004"}
+	var chunks [][]string
+	p := (&ContactDiscoveryParams{Delay: -1, CacheTTL: 24 * time.Hour, NegativeCacheTTL: time.Hour}).withDefaults()
+	results, err := cli.doDiscoverContacts(context.Background(), phones, p, fakeIsOnWhatsApp(&chunks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedChunks := [][]string{{"+15550000003", "+15550000004"}}
+	if !slices.EqualFunc(chunks, expectedChunks, slices.Equal) {
+		t.Fatalf("expected chunks %v, got %v", expectedChunks, chunks)
+	}
+	if !results[0].Cached || !results[0].IsIn || results[0].JID != lid {
+		t.Errorf("unexpected result for cached registered number: %+v", results[0])
+	}
+	if !results[1].Cached || results[1].IsIn {
+		t.Errorf("unexpected result for cached unregistered number: %+v", results[1])
+	}
+	if results[2].Cached || results[3].Cached || !results[3].IsIn {
+		t.Errorf("expired entries were used: %+v %+v", results[2], results[3])
+	}
+	if !cds.entries["15550000004"].CheckedAt.After(now) {
+		t.Error("expired cache entry wasn't replaced")
+	}
+}
+
+func TestDoDiscoverContacts_MissingResult(t *testing.T) {
+	cli, cds := newContactDiscoveryTestClient()
+	phones := []string{"+15550000001", "+15550000002"}
+	var chunks [][]string
+	queryFn := fakeIsOnWhatsApp(&chunks)
+	p := (&ContactDiscoveryParams{Delay: -1}).withDefaults()
+	results, err := cli.doDiscoverContacts(context.Background(), phones, p, func(ctx context.Context, phones []string) ([]types.IsOnWhatsAppResponse, error) {
+		resp, err := queryFn(ctx, phones)
+		return resp[1:], err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(results[0].Err, ErrContactDiscoveryMissingResult) {
+		t.Errorf("expected ErrContactDiscoveryMissingResult, got %v", results[0].Err)
+	} else if results[1].Err != nil || !results[1].IsIn {
+		t.Errorf("unexpected result for answered number: %+v", results[1])
+	}
+	if _, ok := cds.entries["15550000001"]; ok {
+		t.Error("missing number was cached")
+	}
+}
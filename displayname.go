@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// GetDisplayName finds the best name to show for the given user.
+//
+// Both the LID and phone number identities of the user are checked, so it doesn't matter which one is passed.
+// The chat is optional. If it's a group, the participant label (member tag) of the user in that group
+// is included in the result, and used as the name if nothing better is found.
+//
+// The name is chosen in the following order:
+//  1. Your own push name, if the user is you.
+//  2. The full name or first name saved in the address book.
+//  3. The verified business name.
+//  4. The push name.
+//  5. The participant label in the given group.
+//  6. The username, formatted as @username.
+//  7. The redacted phone number, e.g. +1∙∙∙∙∙∙∙∙80, if the real phone number isn't known.
+//  8. The phone number, formatted as +<number>.
+//  9. The user part of the JID.
+//
+// Participant labels are only known for groups whose info has been fetched and is still in the in-memory
+// group cache (see [Client.GroupCacheSize]). Label change notifications are applied to cached groups too.
+func (cli *Client) GetDisplayName(ctx context.Context, jid, chat types.JID) (*types.DisplayName, error) {
+	names, err := cli.GetDisplayNames(ctx, []types.JID{jid}, chat)
+	if err != nil {
+		return nil, err
+	}
+	return names[jid], nil
+}
+
+type displayNameCandidate struct {
+	dn  *types.DisplayName
+	jid types.JID
+}
+
+// GetDisplayNames finds the best names to show for multiple users, e.g. all members of a group.
+// See [Client.GetDisplayName] for the details. The returned map is keyed by the JIDs that were passed in.
+//
+// The LID mappings, contacts and usernames of all users are fetched from the store in batches,
+// so this should be preferred over calling GetDisplayName in a loop.
+func (cli *Client) GetDisplayNames(ctx context.Context, jids []types.JID, chat types.JID) (map[types.JID]*types.DisplayName, error) {
+	var pns, lids []types.JID
+	for _, jid := range jids {
+		switch jid.Server {
+		case types.DefaultUserServer:
+			pns = append(pns, jid.ToNonAD())
+		case types.HiddenUserServer:
+			lids = append(lids, jid.ToNonAD())
+		}
+	}
+	pnToLID, err := cli.Store.LIDs.GetManyLIDsForPNs(ctx, pns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LID mappings: %w", err)
+	}
+	lidToPN, err := cli.Store.LIDs.GetManyPNsForLIDs(ctx, lids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get phone number mappings: %w", err)
+	}
+
+	candidates := make([]displayNameCandidate, 0, len(jids))
+	output := make(map[types.JID]*types.DisplayName, len(jids))
+	contactJIDs := make([]types.JID, 0, len(jids)*2)
+	for _, jid := range jids {
+		if _, alreadyAdded := output[jid]; alreadyAdded {
+			continue
+		}
+		nonAD := jid.ToNonAD()
+		dn := &types.DisplayName{}
+		switch nonAD.Server {
+		case types.HiddenUserServer:
+			dn.LID, dn.PhoneNumber = nonAD, lidToPN[nonAD]
+		case types.DefaultUserServer:
+			dn.PhoneNumber, dn.LID = nonAD, pnToLID[nonAD]
+		}
+		dn.LID, dn.PhoneNumber = dn.LID.ToNonAD(), dn.PhoneNumber.ToNonAD()
+		for _, contactJID := range []types.JID{dn.LID, dn.PhoneNumber} {
+			if !contactJID.IsEmpty() {
+				contactJIDs = append(contactJIDs, contactJID)
+			}
+		}
+		output[jid] = dn
+		candidates = append(candidates, displayNameCandidate{dn: dn, jid: nonAD})
+	}
+	contacts, err := cli.Store.Contacts.GetManyContacts(ctx, contactJIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact info: %w", err)
+	}
+	var labels map[types.JID]string
+	if chat = chat.ToNonAD(); chat.Server == types.GroupServer {
+		cli.groupCacheLock.Lock()
+		labels = cli.groupCache.getLabels(chat)
+		cli.groupCacheLock.Unlock()
+	}
+
+	ownID, ownLID := cli.getOwnID().ToNonAD(), cli.getOwnLID().ToNonAD()
+	needUsername := make([]types.JID, 0)
+	for _, cand := range candidates {
+		dn := cand.dn
+		for _, user := range []types.JID{cand.jid, dn.LID, dn.PhoneNumber} {
+			if label, ok := labels[user]; ok && !user.IsEmpty() {
+				dn.GroupLabel = label
+				break
+			}
+		}
+		pnContact, lidContact := contacts[dn.PhoneNumber], contacts[dn.LID]
+		pick := func(get func(info *types.ContactInfo) string) string {
+			if val := get(&pnContact); val != "" {
+				return val
+			}
+			return get(&lidContact)
+		}
+		isOwn := (!dn.PhoneNumber.IsEmpty() && dn.PhoneNumber == ownID) || (!dn.LID.IsEmpty() && dn.LID == ownLID)
+		if isOwn && cli.Store.PushName != "" {
+			dn.Name, dn.Source = cli.Store.PushName, types.DisplayNameSourcePushName
+		} else if name := pick(func(info *types.ContactInfo) string { return info.FullName }); name != "" {
+			dn.Name, dn.Source = name, types.DisplayNameSourceContact
+		} else if name = pick(func(info *types.ContactInfo) string { return info.FirstName }); name != "" {
+			dn.Name, dn.Source = name, types.DisplayNameSourceContact
+		} else if name = pick(func(info *types.ContactInfo) string { return info.BusinessName }); name != "" {
+			dn.Name, dn.Source = name, types.DisplayNameSourceBusiness
+		} else if name = pick(func(info *types.ContactInfo) string { return info.PushName }); name != "" && name != "-" {
+			dn.Name, dn.Source = name, types.DisplayNameSourcePushName
+		} else if dn.GroupLabel != "" {
+			dn.Name, dn.Source = dn.GroupLabel, types.DisplayNameSourceGroupLabel
+		} else if !dn.LID.IsEmpty() {
+			needUsername = append(needUsername, dn.LID)
+		}
+	}
+	var usernames map[types.JID]string
+	if len(needUsername) > 0 {
+		usernames, err = cli.Store.LIDs.GetManyUsernamesForLIDs(ctx, needUsername)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get usernames: %w", err)
+		}
+	}
+	for _, cand := range candidates {
+		dn := cand.dn
+		if dn.Name != "" {
+			continue
+		} else if username := usernames[dn.LID]; username != "" && !dn.LID.IsEmpty() {
+			dn.Name, dn.Source = "@"+username, types.DisplayNameSourceUsername
+		} else if redactedPhone := contacts[dn.LID].RedactedPhone; redactedPhone != "" && dn.PhoneNumber.IsEmpty() {
+			dn.Name, dn.Source = redactedPhone, types.DisplayNameSourceRedactedPhone
+		} else if !dn.PhoneNumber.IsEmpty() {
+			dn.Name, dn.Source = "+"+dn.PhoneNumber.User, types.DisplayNameSourcePhone
+		} else {
+			dn.Name, dn.Source = cand.jid.User, types.DisplayNameSourceJID
+		}
+	}
+	return output, nil
+}
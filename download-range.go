@@ -0,0 +1,327 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mau.fi/util/retryafter"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/socket"
+)
+
+// StreamingChunkSize is the size of the chunks covered by each MAC in a streaming sidecar.
+const StreamingChunkSize = 64 * 1024
+
+const streamingSidecarMACLength = 10
+
+var (
+	ErrNoStreamingSidecar      = errors.New("message doesn't have a streaming sidecar")
+	ErrInvalidStreamingSidecar = errors.New("streaming sidecar is too short for the file")
+	ErrInvalidChunkHMAC        = errors.New("invalid media chunk hmac")
+	ErrRangeNotSupported       = errors.New("media server didn't return a partial response")
+)
+
+// StreamableMessage represents a protobuf message that contains a streamable attachment.
+//
+// The streamable messages are VideoMessage and AudioMessage.
+type StreamableMessage interface {
+	DownloadableMessage
+	GetFileLength() uint64
+	GetStreamingSidecar() []byte
+}
+
+var (
+	_ StreamableMessage = (*waE2E.VideoMessage)(nil)
+	_ StreamableMessage = (*waE2E.AudioMessage)(nil)
+)
+
+// MediaRangeReader reads a part of an encrypted attachment without downloading the whole file.
+//
+// The reader uses HTTP range requests to fetch only the 64 KiB chunks that are needed, and the streaming sidecar
+// of the message to verify each chunk before decrypting it. Note that the SHA256 hash of the whole file can't be
+// verified when reading this way.
+//
+// Use [Client.NewMediaRangeReader] to create a reader.
+type MediaRangeReader struct {
+	cli         *Client
+	ctx         context.Context
+	directPath  string
+	encFileHash []byte
+	mmsType     string
+
+	iv, cipherKey, macKey []byte
+	sidecar               []byte
+
+	size    int64
+	encSize int64
+	pos     int64
+
+	chunkIndex int64
+	chunk      []byte
+}
+
+var _ io.ReadSeeker = (*MediaRangeReader)(nil)
+
+// NewMediaRangeReader creates a seekable reader for the attachment in the given message.
+//
+// The context is used for all HTTP requests made by the reader.
+//
+//	reader, err := cli.NewMediaRangeReader(ctx, msg.GetVideoMessage())
+//	// handle error
+//	_, err = reader.Seek(1024*1024, io.SeekStart)
+//	// handle error
+//	_, err = io.ReadFull(reader, buf)
+func (cli *Client) NewMediaRangeReader(ctx context.Context, msg StreamableMessage) (*MediaRangeReader, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	mediaType := GetMediaType(msg)
+	if mediaType == "" {
+		return nil, fmt.Errorf("%w %T", ErrUnknownMediaType, msg)
+	} else if len(msg.GetDirectPath()) == 0 {
+		return nil, ErrNoURLPresent
+	} else if !strings.HasPrefix(msg.GetDirectPath(), "/") {
+		return nil, fmt.Errorf("media download path does not start with slash: %s", msg.GetDirectPath())
+	} else if len(msg.GetStreamingSidecar()) == 0 {
+		return nil, ErrNoStreamingSidecar
+	}
+	size := int64(msg.GetFileLength())
+	// CBC with PKCS#7 padding always adds 1-16 bytes of padding
+	encSize := (size/aes.BlockSize + 1) * aes.BlockSize
+	chunkCount := (encSize + StreamingChunkSize - 1) / StreamingChunkSize
+	if int64(len(msg.GetStreamingSidecar())) < chunkCount*streamingSidecarMACLength {
+		return nil, ErrInvalidStreamingSidecar
+	}
+	iv, cipherKey, macKey, _ := getMediaKeys(msg.GetMediaKey(), mediaType)
+	return &MediaRangeReader{
+		cli:         cli,
+		ctx:         ctx,
+		directPath:  msg.GetDirectPath(),
+		encFileHash: msg.GetFileEncSHA256(),
+		mmsType:     mediaTypeToMMSType[mediaType],
+
+		iv:        iv,
+		cipherKey: cipherKey,
+		macKey:    macKey,
+		sidecar:   msg.GetStreamingSidecar(),
+
+		size:    size,
+		encSize: encSize,
+
+		chunkIndex: -1,
+	}, nil
+}
+
+// Size returns the size of the decrypted file.
+func (mrr *MediaRangeReader) Size() int64 {
+	return mrr.size
+}
+
+// Seek implements [io.Seeker]. Seeking doesn't make any requests, data is only fetched when reading.
+func (mrr *MediaRangeReader) Seek(offset int64, whence int) (int64, error) {
+	var newPos int64
+	switch whence {
+	case io.SeekStart:
+		newPos = offset
+	case io.SeekCurrent:
+		newPos = mrr.pos + offset
+	case io.SeekEnd:
+		newPos = mrr.size + offset
+	default:
+		return mrr.pos, fmt.Errorf("invalid whence %d", whence)
+	}
+	if newPos < 0 {
+		return mrr.pos, fmt.Errorf("negative position %d", newPos)
+	}
+	mrr.pos = newPos
+	return newPos, nil
+}
+
+// Read implements [io.Reader]. At most one chunk is fetched per call.
+func (mrr *MediaRangeReader) Read(p []byte) (int, error) {
+	if mrr.pos >= mrr.size {
+		return 0, io.EOF
+	} else if len(p) == 0 {
+		return 0, nil
+	}
+	chunkIndex := mrr.pos / StreamingChunkSize
+	if chunkIndex != mrr.chunkIndex {
+		chunk, err := mrr.fetchChunk(chunkIndex)
+		if err != nil {
+			return 0, err
+		}
+		mrr.chunk = chunk
+		mrr.chunkIndex = chunkIndex
+	}
+	offset := mrr.pos - chunkIndex*StreamingChunkSize
+	if offset >= int64(len(mrr.chunk)) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n := copy(p, mrr.chunk[offset:])
+	mrr.pos += int64(n)
+	return n, nil
+}
+
+func (mrr *MediaRangeReader) fetchChunk(index int64) ([]byte, error) {
+	encStart := index * StreamingChunkSize
+	encEnd := min(encStart+StreamingChunkSize, mrr.encSize)
+	fetchStart := encStart
+	if index > 0 {
+		// The previous CBC block is needed as the IV of this chunk, and it's also covered by the chunk MAC.
+		fetchStart -= aes.BlockSize
+	}
+	data, err := mrr.cli.downloadMediaRange(mrr.ctx, mrr.directPath, mrr.encFileHash, mrr.mmsType, fetchStart, encEnd)
+	if err != nil {
+		return nil, err
+	} else if int64(len(data)) != encEnd-fetchStart {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrTooShortFile, encEnd-fetchStart, len(data))
+	}
+	return mrr.decryptChunk(index, data)
+}
+
+// decryptChunk verifies the given encrypted chunk against the streaming sidecar and decrypts it.
+// For chunks other than the first one, data must be prefixed with the last CBC block of the previous chunk.
+func (mrr *MediaRangeReader) decryptChunk(index int64, data []byte) ([]byte, error) {
+	encStart := index * StreamingChunkSize
+	encEnd := min(encStart+StreamingChunkSize, mrr.encSize)
+	iv, ciphertext := mrr.iv, data
+	if index > 0 {
+		iv, ciphertext = data[:aes.BlockSize], data[aes.BlockSize:]
+	}
+	h := hmac.New(sha256.New, mrr.macKey)
+	h.Write(iv)
+	h.Write(ciphertext)
+	expectedMAC := mrr.sidecar[index*streamingSidecarMACLength : (index+1)*streamingSidecarMACLength]
+	if !hmac.Equal(h.Sum(nil)[:streamingSidecarMACLength], expectedMAC) {
+		return nil, ErrInvalidChunkHMAC
+	}
+	block, err := aes.NewCipher(mrr.cipherKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+	if encEnd == mrr.encSize {
+		// Drop the padding from the last chunk
+		plaintext = plaintext[:mrr.size-encStart]
+	}
+	return plaintext, nil
+}
+
+func (cli *Client) downloadMediaRange(ctx context.Context, directPath string, encFileHash []byte, mmsType string, start, end int64) (data []byte, err error) {
+	err = cli.withMediaConn(ctx, func(mediaConn *MediaConn) error {
+		hosts := cli.getSortedMediaHosts(mediaConn)
+		for i, host := range hosts {
+			mediaURL := fmt.Sprintf("https://%s%s&hash=%s&mms-type=%s&__wa-mms=", host.Hostname, directPath, base64.URLEncoding.EncodeToString(encFileHash), mmsType)
+			data, err = cli.downloadMediaRangeWithRetries(ctx, mediaURL, start, end)
+			if err == nil ||
+				errors.Is(err, ErrRangeNotSupported) ||
+				errors.Is(err, ErrMediaDownloadFailedWith403) ||
+				errors.Is(err, ErrMediaDownloadFailedWith404) ||
+				errors.Is(err, ErrMediaDownloadFailedWith410) ||
+				isMediaAuthExpired(err) ||
+				errors.Is(err, context.Canceled) {
+				return err
+			} else if i >= len(hosts)-1 {
+				return fmt.Errorf("failed to download media range from last host: %w", err)
+			}
+			cli.Log.Warnf("Failed to download media range: %s, trying with next host...", err)
+		}
+		return err
+	})
+	if err != nil {
+		data = nil
+	}
+	return
+}
+
+func (cli *Client) downloadMediaRangeWithRetries(ctx context.Context, url string, start, end int64) (data []byte, err error) {
+	for retryNum := 0; retryNum < 5; retryNum++ {
+		data, err = cli.doMediaRangeRequest(ctx, url, start, end)
+		if err == nil || !shouldRetryMediaDownload(err) {
+			return
+		}
+		retryDuration := time.Duration(retryNum+1) * time.Second
+		var httpErr DownloadHTTPError
+		if errors.As(err, &httpErr) {
+			retryDuration = retryafter.Parse(httpErr.Response.Header.Get("Retry-After"), retryDuration)
+		}
+		cli.Log.Warnf("Failed to download media range due to network error: %v, retrying in %s...", err, retryDuration)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryDuration):
+		}
+	}
+	return
+}
+
+func (cli *Client) doMediaRangeRequest(ctx context.Context, url string, start, end int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare request: %w", err)
+	}
+	req.Header.Set("Origin", socket.Origin)
+	req.Header.Set("Referer", socket.Origin+"/")
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end-1))
+	if userAgent := cli.getUserAgent(); userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+	resp, err := cli.doMediaRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil, ErrRangeNotSupported
+	} else if resp.StatusCode != http.StatusPartialContent {
+		return nil, DownloadHTTPError{Response: resp}
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, end-start))
+}
+
+// generateStreamingSidecar calculates the streaming sidecar for the given ciphertext (without the trailing file MAC).
+//
+// Each 64 KiB chunk of ciphertext is covered by the first 10 bytes of a HMAC-SHA256 over the previous CBC block
+// (or the IV for the first chunk) and the chunk itself.
+func generateStreamingSidecar(iv, macKey []byte, ciphertext io.Reader) ([]byte, error) {
+	var sidecar []byte
+	prevBlock := iv
+	buf := make([]byte, StreamingChunkSize)
+	for {
+		n, err := io.ReadFull(ciphertext, buf)
+		if n > 0 {
+			h := hmac.New(sha256.New, macKey)
+			h.Write(prevBlock)
+			h.Write(buf[:n])
+			sidecar = append(sidecar, h.Sum(nil)[:streamingSidecarMACLength]...)
+			prevBlock = append(prevBlock[:0:0], buf[n-aes.BlockSize:n]...)
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return sidecar, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func mediaTypeHasStreamingSidecar(mediaType MediaType) bool {
+	return mediaType == MediaVideo || mediaType == MediaAudio
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/util/cbcutil"
+)
+
+func encryptTestMedia(t *testing.T, size int) (plaintext, ciphertext []byte, msg *waE2E.VideoMessage) {
+	t.Helper()
+	plaintext = make([]byte, size)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range plaintext {
+		plaintext[i] = byte(rng.Uint32())
+	}
+	mediaKey := bytes.Repeat([]byte{0x42}, 32)
+	iv, cipherKey, macKey, _ := getMediaKeys(mediaKey, MediaVideo)
+	ciphertext, err := cbcutil.Encrypt(cipherKey, iv, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sidecar, err := generateStreamingSidecar(iv, macKey, bytes.NewReader(ciphertext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg = &waE2E.VideoMessage{
+		DirectPath:       proto.String("/v/t62.7161-24/test"),
+		MediaKey:         mediaKey,
+		FileLength:       proto.Uint64(uint64(size)),
+		StreamingSidecar: sidecar,
+	}
+	return
+}
+
+func TestGenerateStreamingSidecar(t *testing.T) {
+	_, ciphertext, msg := encryptTestMedia(t, 2*StreamingChunkSize+1000)
+	iv, _, macKey, _ := getMediaKeys(msg.MediaKey, MediaVideo)
+	sidecar := msg.GetStreamingSidecar()
+	if len(sidecar) != 3*streamingSidecarMACLength {
+		t.Fatalf("expected %d bytes of sidecar, got %d", 3*streamingSidecarMACLength, len(sidecar))
+	}
+	for i := 0; i < 3; i++ {
+		start := i * StreamingChunkSize
+		end := min(start+StreamingChunkSize, len(ciphertext))
+		prevBlock := iv
+		if i > 0 {
+			prevBlock = ciphertext[start-aes.BlockSize : start]
+		}
+		h := hmac.New(sha256.New, macKey)
+		h.Write(prevBlock)
+		h.Write(ciphertext[start:end])
+		expected := h.Sum(nil)[:streamingSidecarMACLength]
+		actual := sidecar[i*streamingSidecarMACLength : (i+1)*streamingSidecarMACLength]
+		if !bytes.Equal(expected, actual) {
+			t.Errorf("chunk %d: expected MAC %x, got %x", i, expected, actual)
+		}
+	}
+}
+
+func TestMediaRangeReader_DecryptChunk(t *testing.T) {
+	plaintext, ciphertext, msg := encryptTestMedia(t, 2*StreamingChunkSize+1000)
+	mrr, err := (&Client{}).NewMediaRangeReader(context.Background(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mrr.encSize != int64(len(ciphertext)) {
+		t.Fatalf("expected encrypted size %d, got %d", len(ciphertext), mrr.encSize)
+	}
+	var decrypted []byte
+	for i := int64(0); i < 3; i++ {
+		start := i * StreamingChunkSize
+		end := min(start+StreamingChunkSize, mrr.encSize)
+		if i > 0 {
+			start -= aes.BlockSize
+		}
+		chunk, err := mrr.decryptChunk(i, ciphertext[start:end])
+		if err != nil {
+			t.Fatalf("chunk %d: %v", i, err)
+		}
+		decrypted = append(decrypted, chunk...)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Error("decrypted data doesn't match plaintext")
+	}
+
+	tampered := bytes.Clone(ciphertext[StreamingChunkSize-aes.BlockSize : 2*StreamingChunkSize])
+	tampered[100] ^= 0xff
+	if _, err = mrr.decryptChunk(1, tampered); !errors.Is(err, ErrInvalidChunkHMAC) {
+		t.Errorf("expected ErrInvalidChunkHMAC for tampered chunk, got %v", err)
+	}
+}
+
+func TestNewMediaRangeReader_ShortSidecar(t *testing.T) {
+	_, _, msg := encryptTestMedia(t, 2*StreamingChunkSize+1000)
+	msg.StreamingSidecar = msg.StreamingSidecar[:2*streamingSidecarMACLength]
+	_, err := (&Client{}).NewMediaRangeReader(context.Background(), msg)
+	if !errors.Is(err, ErrInvalidStreamingSidecar) {
+		t.Errorf("expected ErrInvalidStreamingSidecar, got %v", err)
+	}
+}
@@ -92,30 +92,31 @@ func (cli *Client) DownloadMediaWithPathToFile(
 	if !strings.HasPrefix(directPath, "/") {
 		return fmt.Errorf("media download path does not start with slash: %s", directPath)
 	}
-	mediaConn, err := cli.refreshMediaConn(ctx, false)
-	if err != nil {
-		return fmt.Errorf("failed to refresh media connections: %w", err)
-	}
 	if len(mmsType) == 0 {
 		mmsType = mediaTypeToMMSType[mediaType]
 	}
-	for i, host := range mediaConn.Hosts {
-		// TODO omit hash for unencrypted media?
-		mediaURL := fmt.Sprintf("https://%s%s&hash=%s&mms-type=%s&__wa-mms=", host.Hostname, directPath, base64.URLEncoding.EncodeToString(encFileHash), mmsType)
-		err = cli.downloadAndDecryptToFile(ctx, mediaURL, mediaKey, mediaType, encFileHash, fileHash, file)
-		if err == nil ||
-			errors.Is(err, ErrInvalidMediaSHA256) ||
-			errors.Is(err, ErrMediaDownloadFailedWith403) ||
-			errors.Is(err, ErrMediaDownloadFailedWith404) ||
-			errors.Is(err, ErrMediaDownloadFailedWith410) ||
-			errors.Is(err, context.Canceled) {
-			return err
-		} else if i >= len(mediaConn.Hosts)-1 {
-			return fmt.Errorf("failed to download media from last host: %w", err)
+	return cli.withMediaConn(ctx, func(mediaConn *MediaConn) error {
+		var err error
+		hosts := cli.getSortedMediaHosts(mediaConn)
+		for i, host := range hosts {
+			// TODO omit hash for unencrypted media?
+			mediaURL := fmt.Sprintf("https://%s%s&hash=%s&mms-type=%s&__wa-mms=", host.Hostname, directPath, base64.URLEncoding.EncodeToString(encFileHash), mmsType)
+			err = cli.downloadAndDecryptToFile(ctx, mediaURL, mediaKey, mediaType, encFileHash, fileHash, file)
+			if err == nil ||
+				errors.Is(err, ErrInvalidMediaSHA256) ||
+				errors.Is(err, ErrMediaDownloadFailedWith403) ||
+				errors.Is(err, ErrMediaDownloadFailedWith404) ||
+				errors.Is(err, ErrMediaDownloadFailedWith410) ||
+				isMediaAuthExpired(err) ||
+				errors.Is(err, context.Canceled) {
+				return err
+			} else if i >= len(hosts)-1 {
+				return fmt.Errorf("failed to download media from last host: %w", err)
+			}
+			cli.Log.Warnf("Failed to download media: %s, trying with next host...", err)
 		}
-		cli.Log.Warnf("Failed to download media: %s, trying with next host...", err)
-	}
-	return err
+		return err
+	})
 }
 
 func (cli *Client) downloadAndDecryptToFile(
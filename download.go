@@ -87,6 +87,7 @@ var (
 	_ DownloadableMessage   = (*waE2E.StickerPackMessage)(nil)
 	_ DownloadableMessage   = (*waHistorySync.StickerMetadata)(nil)
 	_ DownloadableMessage   = (*waE2E.HistorySyncNotification)(nil)
+	_ DownloadableMessage   = (*waE2E.MessageHistoryBundle)(nil)
 	_ DownloadableMessage   = (*waServerSync.ExternalBlobReference)(nil)
 	_ DownloadableThumbnail = (*waE2E.ExtendedTextMessage)(nil)
 	_ DownloadableMessage   = (*types.StickerPackItem)(nil)
@@ -102,6 +103,7 @@ var classToMediaType = map[protoreflect.Name]MediaType{
 
 	"StickerPackMessage":      MediaStickerPack,
 	"HistorySyncNotification": MediaHistory,
+	"MessageHistoryBundle":    MediaHistory,
 	"ExternalBlobReference":   MediaAppState,
 }
 
@@ -258,29 +260,32 @@ func (cli *Client) DownloadMediaWithPath(
 	if !strings.HasPrefix(directPath, "/") {
 		return nil, fmt.Errorf("media download path does not start with slash: %s", directPath)
 	}
-	var mediaConn *MediaConn
-	mediaConn, err = cli.refreshMediaConn(ctx, false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to refresh media connections: %w", err)
-	}
 	if len(mmsType) == 0 {
 		mmsType = mediaTypeToMMSType[mediaType]
 	}
-	for i, host := range mediaConn.Hosts {
-		// TODO omit hash for unencrypted media?
-		mediaURL := fmt.Sprintf("https://%s%s&hash=%s&mms-type=%s&__wa-mms=", host.Hostname, directPath, base64.URLEncoding.EncodeToString(encFileHash), mmsType)
-		data, err = cli.downloadAndDecrypt(ctx, mediaURL, mediaKey, mediaType, encFileHash, fileHash)
-		if err == nil ||
-			errors.Is(err, ErrInvalidMediaSHA256) ||
-			errors.Is(err, ErrMediaDownloadFailedWith403) ||
-			errors.Is(err, ErrMediaDownloadFailedWith404) ||
-			errors.Is(err, ErrMediaDownloadFailedWith410) ||
-			errors.Is(err, context.Canceled) {
-			return
-		} else if i >= len(mediaConn.Hosts)-1 {
-			return nil, fmt.Errorf("failed to download media from last host: %w", err)
+	err = cli.withMediaConn(ctx, func(mediaConn *MediaConn) error {
+		hosts := cli.getSortedMediaHosts(mediaConn)
+		for i, host := range hosts {
+			// TODO omit hash for unencrypted media?
+			mediaURL := fmt.Sprintf("https://%s%s&hash=%s&mms-type=%s&__wa-mms=", host.Hostname, directPath, base64.URLEncoding.EncodeToString(encFileHash), mmsType)
+			data, err = cli.downloadAndDecrypt(ctx, mediaURL, mediaKey, mediaType, encFileHash, fileHash)
+			if err == nil ||
+				errors.Is(err, ErrInvalidMediaSHA256) ||
+				errors.Is(err, ErrMediaDownloadFailedWith403) ||
+				errors.Is(err, ErrMediaDownloadFailedWith404) ||
+				errors.Is(err, ErrMediaDownloadFailedWith410) ||
+				isMediaAuthExpired(err) ||
+				errors.Is(err, context.Canceled) {
+				return err
+			} else if i >= len(hosts)-1 {
+				return fmt.Errorf("failed to download media from last host: %w", err)
+			}
+			cli.Log.Warnf("Failed to download media: %s, trying with next host...", err)
 		}
-		cli.Log.Warnf("Failed to download media: %s, trying with next host...", err)
+		return err
+	})
+	if err != nil {
+		data = nil
 	}
 	return
 }
@@ -364,7 +369,7 @@ func (cli *Client) doMediaDownloadRequest(ctx context.Context, url string) (*htt
 	if userAgent := cli.getUserAgent(); userAgent != "" {
 		req.Header.Set("User-Agent", cli.getUserAgent())
 	}
-	resp, err := cli.mediaHTTP.Do(req)
+	resp, err := cli.doMediaRequest(req)
 	if err != nil {
 		return nil, err
 	}
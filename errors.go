@@ -102,6 +102,8 @@ var (
 	ErrUnknownMediaRetryError = errors.New("unknown media retry error")
 	// ErrInvalidDisappearingTimer is returned by SetDisappearingTimer if the given timer is not one of the allowed values.
 	ErrInvalidDisappearingTimer = errors.New("invalid disappearing timer provided")
+	// ErrParticipantLabelUpdateFailed is returned by SetGroupParticipantLabel if the server rejected the label.
+	ErrParticipantLabelUpdateFailed = errors.New("failed to update participant label")
 )
 
 // Some errors that Client.SendMessage can return
@@ -189,6 +191,7 @@ var (
 	ErrIQNotFound            error = &IQError{Code: 404, Text: "item-not-found"}
 	ErrIQNotAllowed          error = &IQError{Code: 405, Text: "not-allowed"}
 	ErrIQNotAcceptable       error = &IQError{Code: 406, Text: "not-acceptable"}
+	ErrIQConflict            error = &IQError{Code: 409, Text: "conflict"}
 	ErrIQGone                error = &IQError{Code: 410, Text: "gone"}
 	ErrIQResourceLimit       error = &IQError{Code: 419, Text: "resource-limit"}
 	ErrIQLocked              error = &IQError{Code: 423, Text: "locked"}
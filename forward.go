@@ -0,0 +1,216 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mau.fi/util/random"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+var (
+	ErrNothingToForward      = errors.New("message doesn't have any forwardable content")
+	ErrMessageNotForwardable = errors.New("message type can't be forwarded")
+	// ErrForwardedMediaUnavailable is returned by BuildForwardWithOptions if [ForwardOptions.CheckMediaAvailable]
+	// is set and the media is no longer available on the media servers.
+	ErrForwardedMediaUnavailable = errors.New("media unavailable, request retry")
+)
+
+// ForwardOptions contains options for [Client.BuildForwardWithOptions].
+type ForwardOptions struct {
+	// If true, media is always downloaded and re-uploaded with a new media key instead of reusing the original one.
+	AlwaysReupload bool
+	// If true, the original file is checked to still be available on the media servers before building the forward.
+	//
+	// If it's not, ErrForwardedMediaUnavailable is returned. Media which has been deleted from the servers
+	// can't be downloaded either, so the sender's phone has to be asked to re-upload it using
+	// [Client.SendMediaRetryReceipt]. After the [events.MediaRetry] event is received, the message can be updated
+	// with the new direct path and forwarded again.
+	CheckMediaAvailable bool
+}
+
+// Message fields that are never copied into forwards.
+var nonForwardedMessageFields = map[protoreflect.Name]struct{}{
+	"messageContextInfo":           {},
+	"senderKeyDistributionMessage": {},
+}
+
+// Message types that can't be forwarded, as they only make sense in relation to another message in the same chat.
+var nonForwardableMessageFields = map[protoreflect.Name]struct{}{
+	"protocolMessage":        {},
+	"reactionMessage":        {},
+	"encReactionMessage":     {},
+	"pollUpdateMessage":      {},
+	"keepInChatMessage":      {},
+	"pinInChatMessage":       {},
+	"secretEncryptedMessage": {},
+	"encCommentMessage":      {},
+}
+
+// BuildForward builds a message that forwards the given received message.
+// The built message can be sent to any chat using Client.SendMessage.
+//
+// Media is forwarded by reference, i.e. the original media keys and direct paths are kept and nothing is re-uploaded.
+// The sender's reply and mention info is removed, and the forwarded flag and forwarding score are set.
+// View once media is unwrapped and forwarded as a normal media message.
+//
+//	msg, err := cli.BuildForward(evt)
+//	// handle error
+//	resp, err := cli.SendMessage(ctx, targetChat, msg)
+func (cli *Client) BuildForward(evt *events.Message) (*waE2E.Message, error) {
+	return cli.BuildForwardWithOptions(context.Background(), evt, ForwardOptions{})
+}
+
+// BuildForwardWithOptions builds a forward message like [Client.BuildForward],
+// but can optionally re-upload the media in the message or check that it's still available.
+func (cli *Client) BuildForwardWithOptions(ctx context.Context, evt *events.Message, opts ForwardOptions) (*waE2E.Message, error) {
+	if evt.Message == nil && evt.RawMessage != nil {
+		evt = (&events.Message{Info: evt.Info, RawMessage: evt.RawMessage}).UnwrapRaw()
+	} else if msg := evt.Message; msg.GetViewOnceMessage() != nil || msg.GetViewOnceMessageV2() != nil || msg.GetViewOnceMessageV2Extension() != nil {
+		evt = (&events.Message{Info: evt.Info, RawMessage: msg}).UnwrapRaw()
+	}
+	if evt.Message == nil {
+		return nil, ErrNothingToForward
+	}
+	fwd := &waE2E.Message{}
+	var content protoreflect.Message
+	var contentField protoreflect.FieldDescriptor
+	// Range doesn't have a defined order, so go through the fields in declaration order and use the first one that's set.
+	// View once messages have already been unwrapped by UnwrapRaw, so the inner media is forwarded as a normal message.
+	msgReflect := evt.Message.ProtoReflect()
+	fields := msgReflect.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !msgReflect.Has(fd) {
+			continue
+		} else if _, skip := nonForwardedMessageFields[fd.Name()]; skip {
+			continue
+		} else if _, notAllowed := nonForwardableMessageFields[fd.Name()]; notAllowed {
+			return nil, fmt.Errorf("%w: %s", ErrMessageNotForwardable, fd.Name())
+		} else if contentField == nil {
+			contentField = fd
+			if fd.Kind() == protoreflect.MessageKind {
+				content = msgReflect.Get(fd).Message()
+			}
+		}
+	}
+	if contentField == nil {
+		return nil, ErrNothingToForward
+	}
+
+	if contentField.Name() == "conversation" {
+		// Plain text messages don't have a context info, so they're converted into extended text messages
+		fwd.ExtendedTextMessage = &waE2E.ExtendedTextMessage{Text: proto.String(evt.Message.GetConversation())}
+		contentField = fwd.ProtoReflect().Descriptor().Fields().ByName("extendedTextMessage")
+		content = fwd.ExtendedTextMessage.ProtoReflect()
+	} else if contentField.Kind() != protoreflect.MessageKind {
+		return nil, fmt.Errorf("%w: %s", ErrMessageNotForwardable, contentField.Name())
+	} else {
+		content = proto.Clone(content.Interface()).ProtoReflect()
+		fwd.ProtoReflect().Set(contentField, protoreflect.ValueOfMessage(content))
+	}
+
+	var oldContextInfo *waE2E.ContextInfo
+	ciField := content.Descriptor().Fields().ByName("contextInfo")
+	if ciField == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMessageNotForwardable, contentField.Name())
+	} else if content.Has(ciField) {
+		oldContextInfo, _ = content.Get(ciField).Message().Interface().(*waE2E.ContextInfo)
+	}
+	content.Set(ciField, protoreflect.ValueOfMessage((&waE2E.ContextInfo{
+		IsForwarded:     proto.Bool(true),
+		ForwardingScore: proto.Uint32(oldContextInfo.GetForwardingScore() + 1),
+		ForwardOrigin:   getForwardOrigin(evt.Info.Chat).Enum(),
+	}).ProtoReflect()))
+	if viewOnceField := content.Descriptor().Fields().ByName("viewOnce"); viewOnceField != nil {
+		content.Clear(viewOnceField)
+	}
+
+	switch {
+	case fwd.PollCreationMessage != nil, fwd.PollCreationMessageV2 != nil, fwd.PollCreationMessageV3 != nil:
+		// Polls need a new secret, as votes are encrypted with it
+		fwd.MessageContextInfo = &waE2E.MessageContextInfo{MessageSecret: random.Bytes(32)}
+	}
+
+	if media, ok := content.Interface().(DownloadableMessage); ok && opts.AlwaysReupload {
+		err := cli.reuploadForwardedMedia(ctx, media)
+		if err != nil {
+			return nil, err
+		}
+	} else if ok && opts.CheckMediaAvailable {
+		available, err := cli.isMediaAvailable(ctx, media)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if media is available: %w", err)
+		} else if !available {
+			return nil, ErrForwardedMediaUnavailable
+		}
+	}
+
+	if evt.IsDocumentWithCaption && fwd.DocumentMessage != nil {
+		fwd = &waE2E.Message{
+			DocumentWithCaptionMessage: &waE2E.FutureProofMessage{
+				Message: fwd,
+			},
+		}
+	}
+	return fwd, nil
+}
+
+func getForwardOrigin(chat types.JID) waE2E.ContextInfo_ForwardOrigin {
+	switch {
+	case chat == types.StatusBroadcastJID:
+		return waE2E.ContextInfo_STATUS
+	case chat.Server == types.NewsletterServer:
+		return waE2E.ContextInfo_CHANNELS
+	default:
+		return waE2E.ContextInfo_CHAT
+	}
+}
+
+func (cli *Client) reuploadForwardedMedia(ctx context.Context, media DownloadableMessage) error {
+	mediaType := GetMediaType(media)
+	if mediaType == "" {
+		return fmt.Errorf("%w %T", ErrUnknownMediaType, media)
+	}
+	data, err := cli.Download(ctx, media)
+	if err != nil {
+		return fmt.Errorf("failed to download media for re-uploading: %w", err)
+	}
+	resp, err := cli.Upload(ctx, data, mediaType)
+	if err != nil {
+		return fmt.Errorf("failed to re-upload media: %w", err)
+	}
+	applyUploadResponse(media, &resp)
+	return nil
+}
+
+// isMediaAvailable checks if the given media can still be downloaded by requesting the first byte of the file.
+func (cli *Client) isMediaAvailable(ctx context.Context, media DownloadableMessage) (bool, error) {
+	mediaType := GetMediaType(media)
+	if mediaType == "" {
+		return false, fmt.Errorf("%w %T", ErrUnknownMediaType, media)
+	} else if media.GetDirectPath() == "" {
+		return false, nil
+	}
+	_, err := cli.downloadMediaRange(ctx, media.GetDirectPath(), media.GetFileEncSHA256(), mediaTypeToMMSType[mediaType], 0, 1)
+	if err == nil || errors.Is(err, ErrRangeNotSupported) {
+		return true, nil
+	} else if errors.Is(err, ErrMediaDownloadFailedWith403) ||
+		errors.Is(err, ErrMediaDownloadFailedWith404) ||
+		errors.Is(err, ErrMediaDownloadFailedWith410) {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestBuildForward_ViewOnce(t *testing.T) {
+	evt := &events.Message{
+		Info: types.MessageInfo{MessageSource: types.MessageSource{Chat: types.NewJID("123", types.DefaultUserServer)}},
+		RawMessage: &waE2E.Message{
+			ViewOnceMessageV2: &waE2E.FutureProofMessage{Message: &waE2E.Message{
+				ImageMessage: &waE2E.ImageMessage{
+					DirectPath: proto.String("/v/test"),
+					ViewOnce:   proto.Bool(true),
+					Caption:    proto.String("secret"),
+				},
+			}},
+		},
+	}
+	fwd, err := (&Client{}).BuildForward(evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := fwd.GetImageMessage()
+	if img == nil {
+		t.Fatalf("expected image message, got %v", fwd)
+	} else if img.ViewOnce != nil {
+		t.Error("view once flag wasn't cleared")
+	} else if !img.GetContextInfo().GetIsForwarded() || img.GetContextInfo().GetForwardingScore() != 1 {
+		t.Errorf("unexpected context info %v", img.GetContextInfo())
+	}
+}
+
+func TestBuildForward_ContentField(t *testing.T) {
+	evt := (&events.Message{RawMessage: &waE2E.Message{
+		Conversation:       proto.String("hello"),
+		MessageContextInfo: &waE2E.MessageContextInfo{MessageSecret: []byte("secret")},
+	}}).UnwrapRaw()
+	for i := 0; i < 10; i++ {
+		fwd, err := (&Client{}).BuildForward(evt)
+		if err != nil {
+			t.Fatal(err)
+		} else if fwd.GetExtendedTextMessage().GetText() != "hello" || fwd.MessageContextInfo != nil {
+			t.Fatalf("unexpected forward %v", fwd)
+		}
+	}
+
+	reaction := (&events.Message{RawMessage: &waE2E.Message{
+		ReactionMessage: &waE2E.ReactionMessage{Text: proto.String("👍")},
+	}}).UnwrapRaw()
+	if _, err := (&Client{}).BuildForward(reaction); !errors.Is(err, ErrMessageNotForwardable) {
+		t.Errorf("expected ErrMessageNotForwardable, got %v", err)
+	}
+}
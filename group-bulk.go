@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+const (
+	// DefaultBulkParticipantChunkSize is the default number of participants changed in one request by the bulk participant functions.
+	DefaultBulkParticipantChunkSize = 20
+	// DefaultBulkParticipantDelay is the default delay between requests in the bulk participant functions.
+	DefaultBulkParticipantDelay = 3 * time.Second
+	// DefaultBulkParticipantMaxRetries is the default number of times a rate-limited request is retried.
+	DefaultBulkParticipantMaxRetries = 3
+)
+
+// GroupParticipantOutcome is the result of changing a single participant in a bulk participant operation.
+type GroupParticipantOutcome string
+
+const (
+	GroupParticipantOutcomeSuccess GroupParticipantOutcome = "success"
+	// The user's privacy settings don't allow adding them directly, an invite message can be sent instead (error 403).
+	GroupParticipantOutcomeInviteRequired GroupParticipantOutcome = "invite_required"
+	// You aren't allowed to change the participant, e.g. because you're not an admin (error 401).
+	GroupParticipantOutcomeNotAuthorized GroupParticipantOutcome = "not_authorized"
+	// The user doesn't exist or isn't in the group or request list (error 404).
+	GroupParticipantOutcomeNotFound GroupParticipantOutcome = "not_found"
+	// The change isn't allowed for this user, e.g. they're not in the community of the group (error 406).
+	GroupParticipantOutcomeNotAcceptable GroupParticipantOutcome = "not_acceptable"
+	// The user recently left the group and can't be added back directly (error 408).
+	GroupParticipantOutcomeRecentlyLeft GroupParticipantOutcome = "recently_left"
+	// The change was already done, e.g. the user is already in the group (error 409).
+	GroupParticipantOutcomeAlreadyDone GroupParticipantOutcome = "already_done"
+	// The group has reached the maximum number of participants (error 419).
+	GroupParticipantOutcomeGroupFull GroupParticipantOutcome = "group_full"
+	// The request was rate limited and retries were exhausted.
+	GroupParticipantOutcomeRateLimited GroupParticipantOutcome = "rate_limited"
+	// The whole request containing the participant failed, see the Err field for details.
+	GroupParticipantOutcomeRequestFailed GroupParticipantOutcome = "request_failed"
+	// The server returned an unrecognized error code or didn't include the participant in the response.
+	GroupParticipantOutcomeUnknown GroupParticipantOutcome = "unknown"
+)
+
+var groupParticipantErrorOutcomes = map[int]GroupParticipantOutcome{
+	401: GroupParticipantOutcomeNotAuthorized,
+	403: GroupParticipantOutcomeInviteRequired,
+	404: GroupParticipantOutcomeNotFound,
+	406: GroupParticipantOutcomeNotAcceptable,
+	408: GroupParticipantOutcomeRecentlyLeft,
+	409: GroupParticipantOutcomeAlreadyDone,
+	419: GroupParticipantOutcomeGroupFull,
+	429: GroupParticipantOutcomeRateLimited,
+}
+
+// GroupParticipantErrorOutcome maps an error code in [types.GroupParticipant] to a typed outcome.
+func GroupParticipantErrorOutcome(code int) GroupParticipantOutcome {
+	if code == 0 || code == 200 {
+		return GroupParticipantOutcomeSuccess
+	} else if outcome, ok := groupParticipantErrorOutcomes[code]; ok {
+		return outcome
+	}
+	return GroupParticipantOutcomeUnknown
+}
+
+// BulkParticipantResult is the outcome of a bulk participant operation for a single user.
+type BulkParticipantResult struct {
+	// The JID that was passed to the bulk function.
+	JID     types.JID
+	Outcome GroupParticipantOutcome
+	// The participant info returned by the server, if the user was included in the response.
+	Participant *types.GroupParticipant
+	// The error that caused the request to fail, if Outcome is request_failed or rate_limited.
+	Err error
+	// The error from sharing group history with the user (if Outcome is success) or sending them
+	// an invite message (if Outcome is invite_required). The participant change itself was still applied.
+	PostProcessErr error
+}
+
+// BulkParticipantParams contains optional parameters for [Client.BulkUpdateGroupParticipants]
+// and [Client.BulkUpdateGroupRequestParticipants].
+type BulkParticipantParams struct {
+	// The number of participants to change in one request. Defaults to DefaultBulkParticipantChunkSize.
+	ChunkSize int
+	// The delay between requests. Defaults to DefaultBulkParticipantDelay, set to a negative value to disable.
+	Delay time.Duration
+	// The number of times a rate-limited request is retried. The delay before each retry is doubled.
+	// Defaults to DefaultBulkParticipantMaxRetries, set to a negative value to disable retries.
+	MaxRetries int
+	// Extra parameters passed to [Client.UpdateGroupParticipants] for each chunk.
+	Extra UpdateGroupParticipantsExtra
+}
+
+func (bpp *BulkParticipantParams) withDefaults() BulkParticipantParams {
+	var out BulkParticipantParams
+	if bpp != nil {
+		out = *bpp
+	}
+	if out.ChunkSize <= 0 {
+		out.ChunkSize = DefaultBulkParticipantChunkSize
+	}
+	if out.Delay == 0 {
+		out.Delay = DefaultBulkParticipantDelay
+	} else if out.Delay < 0 {
+		out.Delay = 0
+	}
+	if out.MaxRetries == 0 {
+		out.MaxRetries = DefaultBulkParticipantMaxRetries
+	} else if out.MaxRetries < 0 {
+		out.MaxRetries = 0
+	}
+	return out
+}
+
+// BulkUpdateGroupParticipants adds, removes, promotes or demotes a large number of group members.
+//
+// The participants are split into chunks that are sent as separate requests with a delay in between,
+// and rate-limited requests are retried with exponential backoff. The returned list contains the outcome
+// for each requested JID in the same order.
+//
+// The error is only non-nil if the context was canceled, including during the last request. In that case,
+// the results for participants that weren't sent yet have the request_failed outcome with the context error
+// in the Err field.
+func (cli *Client) BulkUpdateGroupParticipants(ctx context.Context, jid types.JID, participants []types.JID, action ParticipantChange, params *BulkParticipantParams) ([]BulkParticipantResult, error) {
+	p := params.withDefaults()
+	return cli.doBulkParticipantUpdate(ctx, participants, p, func(chunk []types.JID) ([]types.GroupParticipant, error) {
+		return cli.UpdateGroupParticipants(ctx, jid, chunk, action, p.Extra)
+	})
+}
+
+// BulkUpdateGroupRequestParticipants approves or rejects a large number of requests to join a group.
+// It works the same way as [Client.BulkUpdateGroupParticipants].
+func (cli *Client) BulkUpdateGroupRequestParticipants(ctx context.Context, jid types.JID, participants []types.JID, action ParticipantRequestChange, params *BulkParticipantParams) ([]BulkParticipantResult, error) {
+	return cli.doBulkParticipantUpdate(ctx, participants, params.withDefaults(), func(chunk []types.JID) ([]types.GroupParticipant, error) {
+		return cli.UpdateGroupRequestParticipants(ctx, jid, chunk, action)
+	})
+}
+
+func (cli *Client) doBulkParticipantUpdate(
+	ctx context.Context,
+	participants []types.JID,
+	params BulkParticipantParams,
+	// fn returns both a response and an error if the participants were changed, but post-processing failed
+	fn func(chunk []types.JID) ([]types.GroupParticipant, error),
+) ([]BulkParticipantResult, error) {
+	results := make([]BulkParticipantResult, 0, len(participants))
+	first := true
+	for chunk := range slices.Chunk(participants, params.ChunkSize) {
+		var resp []types.GroupParticipant
+		var err error
+		for attempt := 0; ; attempt++ {
+			delay := params.Delay
+			if attempt > 0 {
+				delay = max(params.Delay, time.Second) << attempt
+			}
+			if !first || attempt > 0 {
+				select {
+				case <-ctx.Done():
+					remaining := makeBulkParticipantResults(participants[len(results):], nil, ctx.Err())
+					return append(results, remaining...), ctx.Err()
+				case <-time.After(delay):
+				}
+			}
+			first = false
+			resp, err = fn(chunk)
+			if !errors.Is(err, ErrIQRateOverLimit) || attempt >= params.MaxRetries {
+				break
+			}
+			cli.Log.Debugf("Bulk participant update was rate limited, retrying (attempt %d/%d)", attempt+1, params.MaxRetries)
+		}
+		var chunkResults []BulkParticipantResult
+		if resp != nil && err != nil {
+			chunkResults = makeBulkParticipantResults(chunk, resp, nil)
+			assignPostProcessErrors(chunkResults, err)
+		} else {
+			chunkResults = makeBulkParticipantResults(chunk, resp, err)
+		}
+		results = append(results, chunkResults...)
+		if ctx.Err() != nil {
+			remaining := makeBulkParticipantResults(participants[len(results):], nil, ctx.Err())
+			return append(results, remaining...), ctx.Err()
+		}
+	}
+	return results, nil
+}
+
+// assignPostProcessErrors attaches the errors from sharing group history and sending invites
+// to the results of the participants that they were attempted for.
+func assignPostProcessErrors(results []BulkParticipantResult, err error) {
+	if err == nil {
+		return
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, err = range errs {
+		var outcome GroupParticipantOutcome
+		switch {
+		case errors.Is(err, ErrGroupHistoryShareFailed):
+			outcome = GroupParticipantOutcomeSuccess
+		case errors.Is(err, ErrGroupInviteSendFailed):
+			outcome = GroupParticipantOutcomeInviteRequired
+		default:
+			continue
+		}
+		for i := range results {
+			if results[i].Outcome == outcome {
+				results[i].PostProcessErr = err
+			}
+		}
+	}
+}
+
+func makeBulkParticipantResults(chunk []types.JID, resp []types.GroupParticipant, err error) []BulkParticipantResult {
+	results := make([]BulkParticipantResult, len(chunk))
+	for i, jid := range chunk {
+		results[i].JID = jid
+		if err != nil {
+			results[i].Err = err
+			if errors.Is(err, ErrIQRateOverLimit) {
+				results[i].Outcome = GroupParticipantOutcomeRateLimited
+			} else {
+				results[i].Outcome = GroupParticipantOutcomeRequestFailed
+			}
+			continue
+		}
+		results[i].Outcome = GroupParticipantOutcomeUnknown
+		for j := range resp {
+			participant := &resp[j]
+			if participant.JID == jid || participant.LID == jid || participant.PhoneNumber == jid {
+				results[i].Participant = participant
+				results[i].Outcome = GroupParticipantErrorOutcome(participant.Error)
+				break
+			}
+		}
+	}
+	return results
+}
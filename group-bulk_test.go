@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	waLog "go.mau.fi/whatsmeow/util/log"
+)
+
+func makeTestUsers(n int) []types.JID {
+	users := make([]types.JID, n)
+	for i := range users {
+		users[i] = types.NewJID(fmt.Sprintf("1555000%04d", i), types.DefaultUserServer)
+	}
+	return users
+}
+
+func TestMakeBulkParticipantResults(t *testing.T) {
+	users := makeTestUsers(4)
+	lid := types.NewJID("123456", types.HiddenUserServer)
+	resp := []types.GroupParticipant{
+		{JID: users[0]},
+		{JID: lid, PhoneNumber: users[1], Error: 403},
+		{JID: users[2], Error: 499},
+	}
+	results := makeBulkParticipantResults(users, resp, nil)
+	expected := []GroupParticipantOutcome{
+		GroupParticipantOutcomeSuccess,
+		GroupParticipantOutcomeInviteRequired,
+		GroupParticipantOutcomeUnknown,
+		GroupParticipantOutcomeUnknown,
+	}
+	for i, result := range results {
+		if result.JID != users[i] || result.Outcome != expected[i] {
+			t.Errorf("result %d: expected %s/%s, got %s/%s", i, users[i], expected[i], result.JID, result.Outcome)
+		}
+	}
+	if results[1].Participant == nil || results[1].Participant.JID != lid {
+		t.Errorf("participant wasn't matched by phone number: %+v", results[1].Participant)
+	} else if results[3].Participant != nil {
+		t.Errorf("unexpected participant for missing user: %+v", results[3].Participant)
+	}
+
+	results = makeBulkParticipantResults(users[:2], nil, ErrIQRateOverLimit)
+	if results[0].Outcome != GroupParticipantOutcomeRateLimited || !errors.Is(results[1].Err, ErrIQRateOverLimit) {
+		t.Errorf("unexpected results for rate limited request: %+v", results)
+	}
+	results = makeBulkParticipantResults(users[:1], nil, ErrIQTimedOut)
+	if results[0].Outcome != GroupParticipantOutcomeRequestFailed || !errors.Is(results[0].Err, ErrIQTimedOut) {
+		t.Errorf("unexpected results for failed request: %+v", results)
+	}
+}
+
+func TestDoBulkParticipantUpdate_Chunking(t *testing.T) {
+	cli := &Client{Log: waLog.Noop}
+	users := makeTestUsers(7)
+	var chunks [][]types.JID
+	results, err := cli.doBulkParticipantUpdate(context.Background(), users, (&BulkParticipantParams{ChunkSize: 3, Delay: -1}).withDefaults(), func(chunk []types.JID) ([]types.GroupParticipant, error) {
+		chunks = append(chunks, chunk)
+		if len(chunks) == 2 {
+			return nil, ErrIQNotAuthorized
+		}
+		resp := make([]types.GroupParticipant, len(chunk))
+		for i, jid := range chunk {
+			resp[i].JID = jid
+		}
+		return resp, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 3 || len(chunks[0]) != 3 || len(chunks[1]) != 3 || len(chunks[2]) != 1 {
+		t.Fatalf("unexpected chunks %v", chunks)
+	}
+	if len(results) != len(users) {
+		t.Fatalf("expected %d results, got %d", len(users), len(results))
+	}
+	for i, result := range results {
+		expected := GroupParticipantOutcomeSuccess
+		if i >= 3 && i < 6 {
+			expected = GroupParticipantOutcomeRequestFailed
+		}
+		if result.JID != users[i] || result.Outcome != expected {
+			t.Errorf("result %d: expected %s/%s, got %s/%s", i, users[i], expected, result.JID, result.Outcome)
+		}
+	}
+}
+
+func TestDoBulkParticipantUpdate_PostProcessErrors(t *testing.T) {
+	cli := &Client{Log: waLog.Noop}
+	users := makeTestUsers(3)
+	historyErr := fmt.Errorf("%w: upload failed", ErrGroupHistoryShareFailed)
+	inviteErr := fmt.Errorf("%w: send failed", ErrGroupInviteSendFailed)
+	results, err := cli.doBulkParticipantUpdate(context.Background(), users, (&BulkParticipantParams{Delay: -1}).withDefaults(), func(chunk []types.JID) ([]types.GroupParticipant, error) {
+		return []types.GroupParticipant{
+			{JID: users[0]},
+			{JID: users[1], Error: 403},
+			{JID: users[2], Error: 409},
+		}, errors.Join(historyErr, inviteErr)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Outcome != GroupParticipantOutcomeSuccess || results[0].Err != nil || results[0].PostProcessErr != historyErr {
+		t.Errorf("unexpected result for added user: %+v", results[0])
+	}
+	if results[1].Outcome != GroupParticipantOutcomeInviteRequired || results[1].PostProcessErr != inviteErr {
+		t.Errorf("unexpected result for invited user: %+v", results[1])
+	}
+	if results[2].Outcome != GroupParticipantOutcomeAlreadyDone || results[2].PostProcessErr != nil {
+		t.Errorf("unexpected result for existing user: %+v", results[2])
+	}
+}
+
+func TestDoBulkParticipantUpdate_Canceled(t *testing.T) {
+	cli := &Client{Log: waLog.Noop}
+	users := makeTestUsers(5)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := cli.doBulkParticipantUpdate(ctx, users, (&BulkParticipantParams{ChunkSize: 2, Delay: time.Hour}).withDefaults(), func(chunk []types.JID) ([]types.GroupParticipant, error) {
+		cancel()
+		return []types.GroupParticipant{{JID: chunk[0]}, {JID: chunk[1]}}, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	resultJIDs := make([]types.JID, len(results))
+	for i, result := range results {
+		resultJIDs[i] = result.JID
+	}
+	if !slices.Equal(resultJIDs, users) {
+		t.Fatalf("expected results for %v, got %v", users, resultJIDs)
+	}
+	for i, result := range results[2:] {
+		if result.Outcome != GroupParticipantOutcomeRequestFailed || !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("result %d: unexpected outcome %s/%v", i+2, result.Outcome, result.Err)
+		}
+	}
+}
+
+func TestBulkParticipantParams_WithDefaults(t *testing.T) {
+	p := (*BulkParticipantParams)(nil).withDefaults()
+	if p.ChunkSize != DefaultBulkParticipantChunkSize || p.Delay != DefaultBulkParticipantDelay || p.MaxRetries != DefaultBulkParticipantMaxRetries {
+		t.Errorf("unexpected defaults %+v", p)
+	}
+	p = (&BulkParticipantParams{ChunkSize: 5, Delay: -1, MaxRetries: -1}).withDefaults()
+	if p.ChunkSize != 5 || p.Delay != 0 || p.MaxRetries != 0 {
+		t.Errorf("unexpected params %+v", p)
+	}
+}
+
+func TestDoBulkParticipantUpdate_CanceledDuringLastChunk(t *testing.T) {
+	cli := &Client{Log: waLog.Noop}
+	users := makeTestUsers(2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := cli.doBulkParticipantUpdate(ctx, users, (&BulkParticipantParams{Delay: -1}).withDefaults(), func(chunk []types.JID) ([]types.GroupParticipant, error) {
+		cancel()
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(results) != len(users) {
+		t.Fatalf("expected %d results, got %d", len(users), len(results))
+	}
+	for i, result := range results {
+		if result.Outcome != GroupParticipantOutcomeRequestFailed || !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("result %d: unexpected outcome %s/%v", i, result.Outcome, result.Err)
+		}
+	}
+}
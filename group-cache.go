@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"container/list"
+	"context"
+	"maps"
+
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// DefaultGroupCacheSize is the default maximum number of groups whose metadata is kept in memory.
+const DefaultGroupCacheSize = 1000
+
+// groupMetaCache is a least-recently-used cache of group metadata. It is not safe for concurrent use,
+// all access must be protected by Client.groupCacheLock.
+type groupMetaCache struct {
+	entries map[types.JID]*list.Element
+	order   *list.List
+}
+
+type groupCacheEntry struct {
+	meta *store.GroupMetadata
+	// Participant labels (member tags) keyed by the non-AD JIDs of the participants.
+	// These are only kept in memory, so they're evicted together with the rest of the entry.
+	labels map[types.JID]string
+}
+
+func newGroupMetaCache() *groupMetaCache {
+	return &groupMetaCache{
+		entries: make(map[types.JID]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (gmc *groupMetaCache) get(jid types.JID) (*store.GroupMetadata, bool) {
+	elem, ok := gmc.entries[jid]
+	if !ok {
+		return nil, false
+	}
+	gmc.order.MoveToFront(elem)
+	return elem.Value.(*groupCacheEntry).meta, true
+}
+
+// put adds or replaces the metadata of a group. Participant labels of an existing entry are kept.
+func (gmc *groupMetaCache) put(meta *store.GroupMetadata, limit int) {
+	if elem, ok := gmc.entries[meta.JID]; ok {
+		elem.Value.(*groupCacheEntry).meta = meta
+		gmc.order.MoveToFront(elem)
+	} else {
+		gmc.entries[meta.JID] = gmc.order.PushFront(&groupCacheEntry{meta: meta})
+	}
+	for limit > 0 && gmc.order.Len() > limit {
+		oldest := gmc.order.Back()
+		gmc.order.Remove(oldest)
+		delete(gmc.entries, oldest.Value.(*groupCacheEntry).meta.JID)
+	}
+}
+
+func (gmc *groupMetaCache) remove(jid types.JID) {
+	if elem, ok := gmc.entries[jid]; ok {
+		gmc.order.Remove(elem)
+		delete(gmc.entries, jid)
+	}
+}
+
+// setLabels replaces all participant labels of a group. Nothing is stored if the group isn't cached.
+func (gmc *groupMetaCache) setLabels(jid types.JID, labels map[types.JID]string) {
+	if elem, ok := gmc.entries[jid]; ok {
+		elem.Value.(*groupCacheEntry).labels = labels
+	}
+}
+
+// setLabel updates the participant label of the given users in a group. An empty label removes it.
+// Nothing is stored if the group isn't cached.
+func (gmc *groupMetaCache) setLabel(jid types.JID, label string, users ...types.JID) {
+	elem, ok := gmc.entries[jid]
+	if !ok {
+		return
+	}
+	entry := elem.Value.(*groupCacheEntry)
+	if entry.labels == nil {
+		if label == "" {
+			return
+		}
+		entry.labels = make(map[types.JID]string)
+	}
+	for _, user := range users {
+		if user.IsEmpty() {
+			continue
+		} else if label == "" {
+			delete(entry.labels, user.ToNonAD())
+		} else {
+			entry.labels[user.ToNonAD()] = label
+		}
+	}
+}
+
+// getLabels returns a copy of the participant labels of a group, or nil if the group isn't cached.
+// Unlike get, this doesn't affect the eviction order.
+func (gmc *groupMetaCache) getLabels(jid types.JID) map[types.JID]string {
+	if elem, ok := gmc.entries[jid]; ok {
+		return maps.Clone(elem.Value.(*groupCacheEntry).labels)
+	}
+	return nil
+}
+
+func (cli *Client) getGroupCacheSize() int {
+	if cli.GroupCacheSize == 0 {
+		return DefaultGroupCacheSize
+	}
+	return cli.GroupCacheSize
+}
+
+// getStoredGroupMetadata finds group metadata from the in-memory cache or the persistent store.
+// The caller must not hold groupCacheLock, the store is queried without holding the lock.
+func (cli *Client) getStoredGroupMetadata(ctx context.Context, jid types.JID) *store.GroupMetadata {
+	cli.groupCacheLock.Lock()
+	meta, ok := cli.groupCache.get(jid)
+	generation := cli.groupCacheGeneration
+	cli.groupCacheLock.Unlock()
+	if ok || cli.Store.Groups == nil {
+		return meta
+	}
+	meta, err := cli.Store.Groups.GetGroupMetadata(ctx, jid)
+	if err != nil {
+		cli.Log.Warnf("Failed to get metadata of %s from store: %v", jid, err)
+		return nil
+	} else if meta == nil {
+		return nil
+	}
+	cli.groupCacheLock.Lock()
+	defer cli.groupCacheLock.Unlock()
+	if cached, ok := cli.groupCache.get(jid); ok {
+		// Someone else filled the cache while we were reading the store
+		return cached
+	} else if generation != cli.groupCacheGeneration {
+		// The group was invalidated while we were reading the store, so the stored data may be outdated
+		return nil
+	}
+	cli.groupCache.put(meta, cli.getGroupCacheSize())
+	return meta
+}
+
+func (cli *Client) persistGroupMetadata(ctx context.Context, metas ...*store.GroupMetadata) {
+	if cli.Store.Groups == nil || len(metas) == 0 {
+		return
+	}
+	err := cli.Store.Groups.PutGroupMetadata(ctx, metas...)
+	if err != nil {
+		cli.Log.Warnf("Failed to store metadata of %d groups: %v", len(metas), err)
+	}
+}
+
+// InvalidateGroupCache removes the cached metadata of the given group from memory and the persistent store,
+// which means it will be fetched from the server again the next time a message is sent to the group.
+func (cli *Client) InvalidateGroupCache(ctx context.Context, jid types.JID) {
+	cli.groupCacheLock.Lock()
+	defer cli.groupCacheLock.Unlock()
+	cli.unlockedInvalidateGroupCache(ctx, jid)
+}
+
+func (cli *Client) unlockedInvalidateGroupCache(ctx context.Context, jid types.JID) {
+	cli.groupCache.remove(jid)
+	cli.groupCacheGeneration++
+	if cli.Store.Groups != nil {
+		err := cli.Store.Groups.DeleteGroupMetadata(ctx, jid)
+		if err != nil {
+			cli.Log.Warnf("Failed to delete metadata of %s from store: %v", jid, err)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"cmp"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/proto/waGroupHistory"
+	"go.mau.fi/whatsmeow/proto/waWeb"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// DefaultGroupHistoryLimit is the default maximum number of messages shared with participants added to a group.
+const DefaultGroupHistoryLimit = 100
+
+const groupHistoryMimeType = "application/x-protobuf"
+
+// MaxGroupHistoryBundleSize is the maximum size of a group history bundle, both before and after decompression.
+const MaxGroupHistoryBundleSize = 16 * 1024 * 1024
+
+var (
+	ErrNoGroupHistoryBundle       = errors.New("message doesn't contain a group history bundle")
+	ErrGroupHistoryShareFailed    = errors.New("failed to share group history")
+	ErrGroupHistoryBundleTooLarge = errors.New("group history bundle is too large")
+)
+
+// BuildGroupHistoryBundle packages the given messages into a group history bundle message.
+//
+// The messages are encrypted and uploaded to the WhatsApp media servers as one file, and the returned message
+// contains the info needed to download them. The receivers are the users who should process the bundle,
+// other group members will ignore it. The returned message should be sent to the group the messages are from.
+//
+// Usually you don't need to call this directly, use the ShareHistory field in [UpdateGroupParticipantsExtra] instead.
+func (cli *Client) BuildGroupHistoryBundle(ctx context.Context, messages []*waWeb.WebMessageInfo, receivers []types.JID) (*waE2E.Message, error) {
+	messages = slices.Clone(messages)
+	slices.SortStableFunc(messages, func(a, b *waWeb.WebMessageInfo) int {
+		return cmp.Compare(a.GetMessageTimestamp(), b.GetMessageTimestamp())
+	})
+	plaintext, err := proto.Marshal(&waGroupHistory.GroupHistory{Messages: messages})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal group history: %w", err)
+	}
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	_, err = zw.Write(plaintext)
+	if err == nil {
+		err = zw.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress group history: %w", err)
+	}
+	resp, err := cli.Upload(ctx, compressed.Bytes(), MediaHistory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload group history: %w", err)
+	}
+	receiverStrings := make([]string, len(receivers))
+	for i, receiver := range receivers {
+		receiverStrings[i] = receiver.ToNonAD().String()
+	}
+	metadata := &waE2E.MessageHistoryMetadata{
+		HistoryReceivers: receiverStrings,
+		MessageCount:     proto.Int64(int64(len(messages))),
+	}
+	if len(messages) > 0 {
+		metadata.OldestMessageTimestampInBundle = proto.Int64(int64(messages[0].GetMessageTimestamp()))
+	}
+	return &waE2E.Message{
+		MessageHistoryBundle: &waE2E.MessageHistoryBundle{
+			Mimetype:               proto.String(groupHistoryMimeType),
+			FileSHA256:             resp.FileSHA256,
+			MediaKey:               resp.MediaKey,
+			FileEncSHA256:          resp.FileEncSHA256,
+			DirectPath:             proto.String(resp.DirectPath),
+			MediaKeyTimestamp:      proto.Int64(time.Now().Unix()),
+			MessageHistoryMetadata: metadata,
+		},
+	}, nil
+}
+
+func (cli *Client) shareGroupHistory(ctx context.Context, group types.JID, participants []types.GroupParticipant, extra UpdateGroupParticipantsExtra) error {
+	receivers := make([]types.JID, 0, len(participants))
+	for _, participant := range participants {
+		if participant.Error == 0 && !participant.JID.IsEmpty() {
+			receivers = append(receivers, participant.JID)
+		}
+	}
+	if len(receivers) == 0 {
+		return nil
+	}
+	messages := extra.ShareHistory
+	limit := cmp.Or(extra.HistoryLimit, DefaultGroupHistoryLimit)
+	if len(messages) > limit {
+		messages = slices.Clone(messages)
+		slices.SortStableFunc(messages, func(a, b *waWeb.WebMessageInfo) int {
+			return cmp.Compare(a.GetMessageTimestamp(), b.GetMessageTimestamp())
+		})
+		messages = messages[len(messages)-limit:]
+	}
+	msg, err := cli.BuildGroupHistoryBundle(ctx, messages, receivers)
+	if err != nil {
+		return err
+	}
+	_, err = cli.SendMessage(ctx, group, msg)
+	return err
+}
+
+func (cli *Client) isGroupHistoryReceiver(bundle *waE2E.MessageHistoryBundle) bool {
+	receivers := bundle.GetMessageHistoryMetadata().GetHistoryReceivers()
+	ownID := cli.getOwnID().ToNonAD()
+	ownLID := cli.getOwnLID().ToNonAD()
+	for _, receiver := range receivers {
+		jid, err := types.ParseJID(receiver)
+		if err != nil {
+			continue
+		}
+		jid = jid.ToNonAD()
+		if (!ownID.IsEmpty() && jid == ownID) || (!ownLID.IsEmpty() && jid == ownLID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cli *Client) handleGroupHistoryBundle(ctx context.Context, evt *events.Message) {
+	messages, err := cli.DownloadGroupHistory(ctx, evt)
+	if err != nil {
+		cli.Log.Errorf("Failed to download group history bundle %s in %s: %v", evt.Info.ID, evt.Info.Chat, err)
+		return
+	}
+	cli.dispatchEvent(&events.GroupHistory{
+		Info:     evt.Info,
+		Metadata: evt.Message.GetMessageHistoryBundle().GetMessageHistoryMetadata(),
+		Messages: messages,
+	})
+}
+
+func decompressGroupHistory(data []byte) ([]byte, error) {
+	// zlib streams start with 0x78, uncompressed protobufs start with the field 1 tag (0x0a)
+	if len(data) == 0 || data[0] != 0x78 {
+		return data, nil
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare to decompress: %w", err)
+	} else if data, err = io.ReadAll(io.LimitReader(reader, MaxGroupHistoryBundleSize+1)); err != nil {
+		return nil, fmt.Errorf("failed to decompress: %w", err)
+	} else if len(data) > MaxGroupHistoryBundleSize {
+		return nil, fmt.Errorf("%w (decompressed size exceeds %d bytes)", ErrGroupHistoryBundleTooLarge, MaxGroupHistoryBundleSize)
+	}
+	return data, nil
+}
+
+// DownloadGroupHistory downloads and parses the messages in a group history bundle.
+//
+// Bundles are sent by any group member, so they're only downloaded automatically if you set
+// [Client.AutomaticGroupHistoryDownload] to true, in which case bundles that list you as a receiver are downloaded
+// and dispatched as an [events.GroupHistory]. Bundles larger than [MaxGroupHistoryBundleSize] are rejected.
+func (cli *Client) DownloadGroupHistory(ctx context.Context, evt *events.Message) ([]*events.Message, error) {
+	bundle := evt.Message.GetMessageHistoryBundle()
+	if bundle == nil {
+		return nil, ErrNoGroupHistoryBundle
+	}
+	data, err := cli.Download(ctx, bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download: %w", err)
+	} else if len(data) > MaxGroupHistoryBundleSize {
+		return nil, fmt.Errorf("%w (%d bytes)", ErrGroupHistoryBundleTooLarge, len(data))
+	}
+	data, err = decompressGroupHistory(data)
+	if err != nil {
+		return nil, err
+	}
+	var history waGroupHistory.GroupHistory
+	err = proto.Unmarshal(data, &history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+	webMsgs := slices.Concat(history.GetMessages(), history.GetCommentMessages(), history.GetOutOfWindowPinnedMessages())
+	for _, list := range history.GetUncountedAssociatedMessageLists() {
+		webMsgs = append(webMsgs, list.GetMessages()...)
+	}
+	messages := make([]*events.Message, 0, len(webMsgs))
+	for _, webMsg := range webMsgs {
+		msgEvt, err := cli.ParseWebMessage(evt.Info.Chat, webMsg)
+		if err != nil {
+			cli.Log.Warnf("Failed to parse message %s in group history bundle %s: %v", webMsg.GetKey().GetID(), evt.Info.ID, err)
+			continue
+		}
+		msgEvt.IsSharedHistory = true
+		messages = append(messages, msgEvt)
+	}
+	slices.SortStableFunc(messages, func(a, b *events.Message) int {
+		return a.Info.Timestamp.Compare(b.Info.Timestamp)
+	})
+	return messages, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"testing"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func zlibCompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	} else if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecompressGroupHistory(t *testing.T) {
+	plain := []byte{0x0a, 0x02, 0x08, 0x01}
+	if out, err := decompressGroupHistory(plain); err != nil || !bytes.Equal(out, plain) {
+		t.Errorf("uncompressed data wasn't passed through: %x, %v", out, err)
+	}
+	if out, err := decompressGroupHistory(zlibCompress(t, plain)); err != nil || !bytes.Equal(out, plain) {
+		t.Errorf("compressed data wasn't decompressed: %x, %v", out, err)
+	}
+	bomb := zlibCompress(t, make([]byte, MaxGroupHistoryBundleSize+1))
+	if _, err := decompressGroupHistory(bomb); !errors.Is(err, ErrGroupHistoryBundleTooLarge) {
+		t.Errorf("expected ErrGroupHistoryBundleTooLarge, got %v", err)
+	}
+}
+
+func TestIsGroupHistoryReceiver(t *testing.T) {
+	ownID := types.NewADJID("123", 0, 5)
+	cli := &Client{Store: &store.Device{ID: &ownID, LID: types.NewJID("456", types.HiddenUserServer)}}
+	makeBundle := func(receivers ...string) *waE2E.MessageHistoryBundle {
+		return &waE2E.MessageHistoryBundle{MessageHistoryMetadata: &waE2E.MessageHistoryMetadata{HistoryReceivers: receivers}}
+	}
+	tests := []struct {
+		name     string
+		bundle   *waE2E.MessageHistoryBundle
+		expected bool
+	}{
+		{"NoReceivers", makeBundle(), false},
+		{"OtherReceiver", makeBundle("789@s.whatsapp.net"), false},
+		{"PhoneNumber", makeBundle("789@s.whatsapp.net", "123@s.whatsapp.net"), true},
+		{"LID", makeBundle("456@lid"), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := cli.isGroupHistoryReceiver(test.bundle); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
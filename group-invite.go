@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+var ErrGroupInviteSendFailed = errors.New("failed to send group invite messages")
+
+// BuildGroupInviteMessage builds a message that invites a user to a group.
+//
+// The add request is the one returned by [Client.UpdateGroupParticipants] for participants who couldn't be added
+// directly (error 403). The message must be sent to that participant, and it can only be used by them.
+// The thumbnail is optional, it should be a small JPEG of the group picture, see [Client.GetGroupInviteThumbnail].
+//
+// To send the invites automatically, use the SendInvites field in [UpdateGroupParticipantsExtra] instead.
+func (cli *Client) BuildGroupInviteMessage(group *types.GroupInfo, addRequest *types.GroupParticipantAddRequest, caption string, thumbnail []byte) *waE2E.Message {
+	groupType := waE2E.GroupInviteMessage_DEFAULT
+	if group.IsParent {
+		groupType = waE2E.GroupInviteMessage_PARENT
+	}
+	msg := &waE2E.GroupInviteMessage{
+		GroupJID:         proto.String(group.JID.String()),
+		InviteCode:       proto.String(addRequest.Code),
+		InviteExpiration: proto.Int64(addRequest.Expiration.Unix()),
+		GroupName:        proto.String(group.Name),
+		JPEGThumbnail:    thumbnail,
+		GroupType:        groupType.Enum(),
+	}
+	if caption != "" {
+		msg.Caption = proto.String(caption)
+	}
+	return &waE2E.Message{GroupInviteMessage: msg}
+}
+
+// GetGroupInviteThumbnail downloads the preview-sized picture of a group for use in [Client.BuildGroupInviteMessage].
+//
+// If the group doesn't have a picture, this returns nil without an error.
+func (cli *Client) GetGroupInviteThumbnail(ctx context.Context, group types.JID) ([]byte, error) {
+	info, err := cli.GetProfilePictureInfo(ctx, group, &GetProfilePictureParams{Preview: true})
+	if errors.Is(err, ErrProfilePictureNotSet) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get picture info: %w", err)
+	} else if info == nil || info.URL == "" {
+		return nil, nil
+	}
+	data, err := cli.downloadMedia(ctx, info.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download picture: %w", err)
+	}
+	return data, nil
+}
+
+// RevokeGroupInviteRequest revokes the invite that was created when trying to add a user to a group,
+// so that any invite message sent to them can no longer be used to join the group.
+func (cli *Client) RevokeGroupInviteRequest(ctx context.Context, group, user types.JID) error {
+	_, err := cli.sendGroupIQ(ctx, iqSet, group, waBinary.Node{
+		Tag: "revoke",
+		Content: []waBinary.Node{{
+			Tag:   "participant",
+			Attrs: waBinary.Attrs{"jid": user},
+		}},
+	})
+	return err
+}
+
+func (cli *Client) sendGroupAddInvites(ctx context.Context, group types.JID, participants []types.GroupParticipant, caption string) error {
+	var invitees []types.GroupParticipant
+	for _, participant := range participants {
+		if participant.Error == 403 && participant.AddRequest != nil {
+			invitees = append(invitees, participant)
+		}
+	}
+	if len(invitees) == 0 {
+		return nil
+	}
+	groupInfo, err := cli.GetGroupInfo(ctx, group)
+	if err != nil {
+		return fmt.Errorf("failed to get group info: %w", err)
+	}
+	thumbnail, err := cli.GetGroupInviteThumbnail(ctx, group)
+	if err != nil {
+		cli.Log.Warnf("Failed to get thumbnail for invites to %s: %v", group, err)
+	}
+	var errs []error
+	for _, invitee := range invitees {
+		msg := cli.BuildGroupInviteMessage(groupInfo, invitee.AddRequest, caption, thumbnail)
+		_, err = cli.SendMessage(ctx, invitee.JID, msg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", invitee.JID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
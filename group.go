@@ -11,8 +11,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+
+	"go.mau.fi/util/jsontime"
 
 	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/proto/waWeb"
 	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
@@ -204,8 +209,32 @@ const (
 	ParticipantChangeDemote  ParticipantChange = "demote"
 )
 
+// UpdateGroupParticipantsExtra contains optional parameters for [Client.UpdateGroupParticipants].
+type UpdateGroupParticipantsExtra struct {
+	// Recent messages in the group to share with the added participants. Only used with ParticipantChangeAdd.
+	//
+	// The messages are packaged into a group history bundle, which is sent to the group after the participants
+	// have been added. Only the participants who were added successfully are marked as receivers of the history.
+	ShareHistory []*waWeb.WebMessageInfo
+	// The maximum number of messages to share. If there are more messages in ShareHistory, only the newest ones are shared.
+	// Defaults to DefaultGroupHistoryLimit.
+	HistoryLimit int
+
+	// If true, participants who can't be added directly because of their privacy settings (i.e. the ones that
+	// have error 403 and an AddRequest in the response) are sent a group invite message automatically.
+	// Only used with ParticipantChangeAdd.
+	SendInvites bool
+	// The caption to include in the automatically sent invite messages.
+	InviteCaption string
+}
+
 // UpdateGroupParticipants can be used to add, remove, promote and demote members in a WhatsApp group.
-func (cli *Client) UpdateGroupParticipants(ctx context.Context, jid types.JID, participantChanges []types.JID, action ParticipantChange) ([]types.GroupParticipant, error) {
+//
+// When adding participants, recent messages can be shared with them using the ShareHistory field in the extra parameter,
+// and users who can't be added directly can be sent invite messages using the SendInvites field.
+// If the participants were added, but sharing the history or sending invites failed, the participant list is returned
+// along with an error wrapping ErrGroupHistoryShareFailed or ErrGroupInviteSendFailed.
+func (cli *Client) UpdateGroupParticipants(ctx context.Context, jid types.JID, participantChanges []types.JID, action ParticipantChange, extra ...UpdateGroupParticipantsExtra) ([]types.GroupParticipant, error) {
 	content := make([]waBinary.Node, len(participantChanges))
 	for i, participantJID := range participantChanges {
 		content[i] = waBinary.Node{
@@ -248,7 +277,23 @@ func (cli *Client) UpdateGroupParticipants(ctx context.Context, jid types.JID, p
 	for i, child := range requestParticipants {
 		participants[i] = parseParticipant(child.AttrGetter(), &child)
 	}
-	return participants, nil
+	if action != ParticipantChangeAdd || len(extra) == 0 {
+		return participants, nil
+	}
+	var errs []error
+	if len(extra[0].ShareHistory) > 0 {
+		err = cli.shareGroupHistory(ctx, jid, participants, extra[0])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrGroupHistoryShareFailed, err))
+		}
+	}
+	if extra[0].SendInvites {
+		err = cli.sendGroupAddInvites(ctx, jid, participants, extra[0].InviteCaption)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrGroupInviteSendFailed, err))
+		}
+	}
+	return participants, errors.Join(errs...)
 }
 
 // GetGroupRequestParticipants gets the list of participants that have requested to join the group.
@@ -549,6 +594,7 @@ func (cli *Client) GetJoinedGroups(ctx context.Context) ([]*types.GroupInfo, err
 	}
 	children := groups.GetChildren()
 	infos := make([]*types.GroupInfo, 0, len(children))
+	allMetas := make([]*store.GroupMetadata, 0, len(children))
 	var allLIDPairs []store.LIDMapping
 	var allRedactedPhones []store.RedactedPhoneEntry
 	for _, child := range children {
@@ -560,11 +606,13 @@ func (cli *Client) GetJoinedGroups(ctx context.Context) ([]*types.GroupInfo, err
 		if parseErr != nil {
 			cli.Log.Warnf("Error parsing group %s: %v", parsed.JID, parseErr)
 		}
-		lidPairs, redactedPhones := cli.cacheGroupInfo(parsed, true)
+		meta, lidPairs, redactedPhones := cli.cacheGroupInfo(parsed, true)
+		allMetas = append(allMetas, meta)
 		allLIDPairs = append(allLIDPairs, lidPairs...)
 		allRedactedPhones = append(allRedactedPhones, redactedPhones...)
 		infos = append(infos, parsed)
 	}
+	cli.persistGroupMetadata(ctx, allMetas...)
 	err = cli.Store.LIDs.PutManyLIDMappings(ctx, allLIDPairs)
 	if err != nil {
 		cli.Log.Warnf("Failed to store LID mappings from joined groups: %v", err)
@@ -624,12 +672,228 @@ func (cli *Client) GetGroupInfo(ctx context.Context, jid types.JID) (*types.Grou
 	return cli.getGroupInfo(ctx, jid, true)
 }
 
-func (cli *Client) cacheGroupInfo(groupInfo *types.GroupInfo, lock bool) ([]store.LIDMapping, []store.RedactedPhoneEntry) {
+// BatchGroupInfoSize is the maximum number of groups requested in one query by [Client.GetGroupInfos].
+const BatchGroupInfoSize = 50
+
+type mexGroupUser struct {
+	ID          types.JID `json:"id"`
+	LID         types.JID `json:"lid"`
+	PN          types.JID `json:"pn"`
+	DisplayName string    `json:"display_name"`
+}
+
+type mexGroupInfo struct {
+	ID                     types.JID           `json:"id"`
+	TotalParticipantsCount int                 `json:"total_participants_count"`
+	State                  string              `json:"state"`
+	CreationTime           jsontime.UnixString `json:"creation_time"`
+	AnnouncementVersion    string              `json:"announcement_version"`
+	Creator                *struct {
+		mexGroupUser
+		CountryCode string `json:"country_code"`
+	} `json:"creator"`
+	Subject *struct {
+		CreationTime jsontime.UnixString `json:"creation_time"`
+		Value        string              `json:"value"`
+		Creator      *mexGroupUser       `json:"creator"`
+	} `json:"subject"`
+	Description *struct {
+		ID           string              `json:"id"`
+		CreationTime jsontime.UnixString `json:"creation_time"`
+		Value        string              `json:"value"`
+		Creator      *mexGroupUser       `json:"creator"`
+	} `json:"description"`
+	Participants *struct {
+		Edges []struct {
+			Node mexGroupUser               `json:"node"`
+			Role types.GroupParticipantRole `json:"role"`
+		} `json:"edges"`
+	} `json:"participants"`
+	Properties *struct {
+		Announcement                  bool `json:"announcement"`
+		Locked                        bool `json:"locked"`
+		MembershipApprovalModeEnabled bool `json:"membership_approval_mode_enabled"`
+		Ephemeral                     *struct {
+			ExpirationTimeInSec uint32 `json:"expiration_time_in_sec"`
+		} `json:"ephemeral"`
+		LIDMigrationState *struct {
+			AddressingMode string `json:"addressing_mode"`
+		} `json:"lid_migration_state"`
+		MemberAddMode  string    `json:"member_add_mode"`
+		ParentGroupJID types.JID `json:"parent_group_jid"`
+	} `json:"properties"`
+	ResponseCode string `json:"response_code"`
+}
+
+func (mgu *mexGroupUser) pnAndLID() (pn, lid types.JID) {
+	if mgu.ID.Server == types.HiddenUserServer {
+		return mgu.PN, mgu.ID
+	}
+	return cmp.Or(mgu.PN, mgu.ID), mgu.LID
+}
+
+func (mgi *mexGroupInfo) toGroupInfo() *types.GroupInfo {
+	info := &types.GroupInfo{
+		JID:              mgi.ID,
+		GroupCreated:     mgi.CreationTime.Time,
+		ParticipantCount: mgi.TotalParticipantsCount,
+		Suspended:        mgi.State == "SUSPENDED",
+	}
+	info.AnnounceVersionID = mgi.AnnouncementVersion
+	if mgi.Creator != nil {
+		info.OwnerJID = mgi.Creator.ID
+		info.OwnerPN, _ = mgi.Creator.pnAndLID()
+		info.CreatorCountryCode = mgi.Creator.CountryCode
+	}
+	if mgi.Subject != nil {
+		info.Name = mgi.Subject.Value
+		info.NameSetAt = mgi.Subject.CreationTime.Time
+		if mgi.Subject.Creator != nil {
+			info.NameSetBy = mgi.Subject.Creator.ID
+			info.NameSetByPN, _ = mgi.Subject.Creator.pnAndLID()
+		}
+	}
+	if mgi.Description != nil {
+		info.Topic = mgi.Description.Value
+		info.TopicID = mgi.Description.ID
+		info.TopicSetAt = mgi.Description.CreationTime.Time
+		if mgi.Description.Creator != nil {
+			info.TopicSetBy = mgi.Description.Creator.ID
+			info.TopicSetByPN, _ = mgi.Description.Creator.pnAndLID()
+		}
+	}
+	if props := mgi.Properties; props != nil {
+		info.IsAnnounce = props.Announcement
+		info.IsLocked = props.Locked
+		info.IsJoinApprovalRequired = props.MembershipApprovalModeEnabled
+		info.MemberAddMode = types.GroupMemberAddMode(strings.ToLower(props.MemberAddMode))
+		info.LinkedParentJID = props.ParentGroupJID
+		if props.Ephemeral != nil && props.Ephemeral.ExpirationTimeInSec > 0 {
+			info.IsEphemeral = true
+			info.DisappearingTimer = props.Ephemeral.ExpirationTimeInSec
+		}
+		if props.LIDMigrationState != nil {
+			info.AddressingMode = types.AddressingMode(strings.ToLower(props.LIDMigrationState.AddressingMode))
+		}
+	}
+	if mgi.Participants != nil {
+		info.Participants = make([]types.GroupParticipant, len(mgi.Participants.Edges))
+		for i, edge := range mgi.Participants.Edges {
+			pn, lid := edge.Node.pnAndLID()
+			info.Participants[i] = types.GroupParticipant{
+				JID:          edge.Node.ID,
+				PhoneNumber:  pn,
+				LID:          lid,
+				IsAdmin:      edge.Role == types.GroupParticipantRoleAdmin || edge.Role == types.GroupParticipantRoleSuperAdmin,
+				IsSuperAdmin: edge.Role == types.GroupParticipantRoleSuperAdmin,
+				DisplayName:  edge.Node.DisplayName,
+			}
+		}
+	}
+	return info
+}
+
+type respGetGroupInfos struct {
+	Groups []*mexGroupInfo `json:"xwa2_group_batch_query_by_id"`
+}
+
+// GetGroupInfos requests info about multiple group chats at once. The groups are requested in batches of
+// [BatchGroupInfoSize], so this is much faster than calling [Client.GetGroupInfo] for each group separately.
+//
+// The fetched group metadata is also stored in the group cache, so this can be used to warm up the cache
+// before sending messages to many groups. Groups that couldn't be fetched (e.g. because you're not a participant)
+// are not included in the returned map.
+//
+// The batch query doesn't say whether a community subgroup is the announcement group of the community,
+// so the subgroups of each community with announcement-only subgroups in the batch are fetched separately
+// to fill IsDefaultSubGroup.
+func (cli *Client) GetGroupInfos(ctx context.Context, jids []types.JID) (map[types.JID]*types.GroupInfo, error) {
+	infos := make(map[types.JID]*types.GroupInfo, len(jids))
+	metas := make([]*store.GroupMetadata, 0, len(jids))
+	defaultSubGroups := make(map[types.JID]types.JID)
+	var allLIDPairs []store.LIDMapping
+	var allRedactedPhones []store.RedactedPhoneEntry
+	for chunk := range slices.Chunk(jids, BatchGroupInfoSize) {
+		groupIDs := make([]string, len(chunk))
+		for i, jid := range chunk {
+			groupIDs[i] = jid.String()
+		}
+		resp, err := MexQuery[respGetGroupInfos](ctx, cli, "QueryBatchGetGroups", map[string]any{
+			"group_ids": groupIDs,
+		})
+		if err != nil {
+			return infos, err
+		}
+		for _, group := range resp.Groups {
+			if group == nil {
+				continue
+			} else if group.ResponseCode != "" && group.ResponseCode != "OK" {
+				cli.Log.Debugf("Failed to get info of %s in batch query: %s", group.ID, group.ResponseCode)
+				continue
+			}
+			info := group.toGroupInfo()
+			infos[info.JID] = info
+			if info.IsAnnounce && !info.LinkedParentJID.IsEmpty() {
+				defaultSubGroup, ok := defaultSubGroups[info.LinkedParentJID]
+				if !ok {
+					defaultSubGroup = cli.getDefaultSubGroup(ctx, info.LinkedParentJID)
+					defaultSubGroups[info.LinkedParentJID] = defaultSubGroup
+				}
+				if defaultSubGroup.IsEmpty() {
+					// Don't cache groups whose announcement group status is unknown,
+					// the send path addresses community announcement groups differently
+					continue
+				}
+				info.IsDefaultSubGroup = defaultSubGroup == info.JID
+			}
+			if group.Participants == nil {
+				// Don't cache groups without a member list, it would break sending messages to them
+				continue
+			}
+			meta, lidPairs, redactedPhones := cli.cacheGroupInfo(info, true)
+			metas = append(metas, meta)
+			allLIDPairs = append(allLIDPairs, lidPairs...)
+			allRedactedPhones = append(allRedactedPhones, redactedPhones...)
+		}
+	}
+	cli.persistGroupMetadata(ctx, metas...)
+	err := cli.Store.LIDs.PutManyLIDMappings(ctx, allLIDPairs)
+	if err != nil {
+		cli.Log.Warnf("Failed to store LID mappings from batch group info query: %v", err)
+	}
+	err = cli.Store.Contacts.PutManyRedactedPhones(ctx, allRedactedPhones)
+	if err != nil {
+		cli.Log.Warnf("Failed to store redacted phones from batch group info query: %v", err)
+	}
+	return infos, nil
+}
+
+func (cli *Client) getDefaultSubGroup(ctx context.Context, community types.JID) types.JID {
+	group, err := cli.GetCommunityAnnouncementGroup(ctx, community)
+	if err != nil {
+		cli.Log.Warnf("Failed to get announcement group of community %s: %v", community, err)
+		return types.EmptyJID
+	}
+	return group.JID
+}
+
+func (cli *Client) cacheGroupInfo(groupInfo *types.GroupInfo, lock bool) (*store.GroupMetadata, []store.LIDMapping, []store.RedactedPhoneEntry) {
 	participants := make([]types.JID, len(groupInfo.Participants))
 	lidPairs := make([]store.LIDMapping, len(groupInfo.Participants))
 	redactedPhones := make([]store.RedactedPhoneEntry, 0)
+	var labels map[types.JID]string
 	for i, part := range groupInfo.Participants {
 		participants[i] = part.JID
+		if part.Label != "" {
+			if labels == nil {
+				labels = make(map[types.JID]string)
+			}
+			for _, jid := range []types.JID{part.JID, part.LID, part.PhoneNumber} {
+				if !jid.IsEmpty() {
+					labels[jid.ToNonAD()] = part.Label
+				}
+			}
+		}
 		if !part.PhoneNumber.IsEmpty() && !part.LID.IsEmpty() {
 			lidPairs[i] = store.LIDMapping{
 				LID: part.LID,
@@ -643,16 +907,20 @@ func (cli *Client) cacheGroupInfo(groupInfo *types.GroupInfo, lock bool) ([]stor
 			})
 		}
 	}
-	if lock {
-		cli.groupCacheLock.Lock()
-		defer cli.groupCacheLock.Unlock()
-	}
-	cli.groupCache[groupInfo.JID] = &groupMetaCache{
+	meta := &store.GroupMetadata{
+		JID:                        groupInfo.JID,
 		AddressingMode:             groupInfo.AddressingMode,
 		CommunityAnnouncementGroup: groupInfo.IsAnnounce && groupInfo.IsDefaultSubGroup,
+		ParticipantVersionID:       groupInfo.ParticipantVersionID,
 		Members:                    participants,
 	}
-	return lidPairs, redactedPhones
+	if lock {
+		cli.groupCacheLock.Lock()
+		defer cli.groupCacheLock.Unlock()
+	}
+	cli.groupCache.put(meta, cli.getGroupCacheSize())
+	cli.groupCache.setLabels(groupInfo.JID, labels)
+	return meta, lidPairs, redactedPhones
 }
 
 func (cli *Client) getGroupInfo(ctx context.Context, jid types.JID, lockParticipantCache bool) (*types.GroupInfo, error) {
@@ -676,7 +944,8 @@ func (cli *Client) getGroupInfo(ctx context.Context, jid types.JID, lockParticip
 	if err != nil {
 		return groupInfo, err
 	}
-	lidPairs, redactedPhones := cli.cacheGroupInfo(groupInfo, lockParticipantCache)
+	meta, lidPairs, redactedPhones := cli.cacheGroupInfo(groupInfo, lockParticipantCache)
+	cli.persistGroupMetadata(ctx, meta)
 	err = cli.Store.LIDs.PutManyLIDMappings(ctx, lidPairs)
 	if err != nil {
 		cli.Log.Warnf("Failed to store LID mappings for members of %s: %v", jid, err)
@@ -688,17 +957,22 @@ func (cli *Client) getGroupInfo(ctx context.Context, jid types.JID, lockParticip
 	return groupInfo, nil
 }
 
-func (cli *Client) getCachedGroupData(ctx context.Context, jid types.JID) (*groupMetaCache, error) {
+func (cli *Client) getCachedGroupData(ctx context.Context, jid types.JID) (*store.GroupMetadata, error) {
+	if val := cli.getStoredGroupMetadata(ctx, jid); val != nil {
+		return val, nil
+	}
 	cli.groupCacheLock.Lock()
 	defer cli.groupCacheLock.Unlock()
-	if val, ok := cli.groupCache[jid]; ok {
+	if val, ok := cli.groupCache.get(jid); ok {
+		// Another goroutine fetched the group while we were waiting for the lock
 		return val, nil
 	}
 	_, err := cli.getGroupInfo(ctx, jid, false)
 	if err != nil {
 		return nil, err
 	}
-	return cli.groupCache[jid], nil
+	val, _ := cli.groupCache.get(jid)
+	return val, nil
 }
 
 func parseParticipant(childAG *waBinary.AttrUtility, child *waBinary.Node) types.GroupParticipant {
@@ -708,6 +982,7 @@ func parseParticipant(childAG *waBinary.AttrUtility, child *waBinary.Node) types
 		IsSuperAdmin: pcpType == "superadmin",
 		JID:          childAG.JID("jid"),
 		DisplayName:  childAG.OptionalString("display_name"),
+		Label:        childAG.OptionalString("label"),
 	}
 	if participant.JID.Server == types.HiddenUserServer {
 		participant.LID = participant.JID
@@ -789,6 +1064,8 @@ func (cli *Client) parseGroupNode(groupNode *waBinary.Node) (*types.GroupInfo, e
 			group.IsJoinApprovalRequired = true
 		case "suspended":
 			group.Suspended = true
+		case "participant_label_enabled":
+			group.ParticipantLabelsEnabled = true
 		default:
 			cli.Log.Debugf("Unknown element in group node %s: %s", group.JID.String(), &child)
 		}
@@ -848,7 +1125,7 @@ func parseParticipantList(node *waBinary.Node) (participants []types.JID, lidPai
 	return
 }
 
-func (cli *Client) parseGroupCreate(parentNode, node *waBinary.Node) (*events.JoinedGroup, []store.LIDMapping, []store.RedactedPhoneEntry, error) {
+func (cli *Client) parseGroupCreate(ctx context.Context, parentNode, node *waBinary.Node) (*events.JoinedGroup, []store.LIDMapping, []store.RedactedPhoneEntry, error) {
 	groupNode, ok := node.GetOptionalChildByTag("group")
 	if !ok {
 		return nil, nil, nil, fmt.Errorf("group create notification didn't contain group info")
@@ -870,7 +1147,8 @@ func (cli *Client) parseGroupCreate(parentNode, node *waBinary.Node) (*events.Jo
 		info.AddressingMode = types.AddressingMode(pag.OptionalString("addressing_mode"))
 	}
 	evt.GroupInfo = *info
-	lidPairs, redactedPhones := cli.cacheGroupInfo(info, true)
+	meta, lidPairs, redactedPhones := cli.cacheGroupInfo(info, true)
+	cli.persistGroupMetadata(ctx, meta)
 	return &evt, lidPairs, redactedPhones, nil
 }
 
@@ -1004,16 +1282,42 @@ func (cli *Client) parseGroupChange(node *waBinary.Node) (*events.GroupInfo, []s
 	return &evt, lidPairs, nil
 }
 
-func (cli *Client) updateGroupParticipantCache(evt *events.GroupInfo) {
+func (cli *Client) updateGroupParticipantCache(ctx context.Context, evt *events.GroupInfo) {
+	if evt.Delete != nil || evt.Announce != nil {
+		cli.InvalidateGroupCache(ctx, evt.JID)
+		return
+	}
 	// TODO can the addressing mode change here?
 	if len(evt.Join) == 0 && len(evt.Leave) == 0 {
 		return
 	}
+	if cli.getStoredGroupMetadata(ctx, evt.JID) == nil {
+		return
+	}
 	cli.groupCacheLock.Lock()
 	defer cli.groupCacheLock.Unlock()
-	cached, ok := cli.groupCache[evt.JID]
+	cached, ok := cli.groupCache.get(evt.JID)
 	if !ok {
 		return
+	} else if evt.PrevParticipantVersionID != "" && cached.ParticipantVersionID != "" && evt.PrevParticipantVersionID != cached.ParticipantVersionID {
+		cli.Log.Debugf(
+			"Participant version of %s doesn't match cache (%s != %s), dropping cached metadata",
+			evt.JID, evt.PrevParticipantVersionID, cached.ParticipantVersionID,
+		)
+		cli.unlockedInvalidateGroupCache(ctx, evt.JID)
+		return
+	}
+	ownID := cli.getOwnID().ToNonAD()
+	ownLID := cli.getOwnLID().ToNonAD()
+	for _, jid := range evt.Leave {
+		if jid == ownID || jid == ownLID {
+			cli.unlockedInvalidateGroupCache(ctx, evt.JID)
+			return
+		}
+	}
+	defer cli.persistGroupMetadata(ctx, cached)
+	if evt.ParticipantVersionID != "" {
+		cached.ParticipantVersionID = evt.ParticipantVersionID
 	}
 Outer:
 	for _, jid := range evt.Join {
@@ -1035,16 +1339,16 @@ Outer:
 	}
 }
 
-func (cli *Client) parseGroupNotification(node *waBinary.Node) (any, []store.LIDMapping, []store.RedactedPhoneEntry, error) {
+func (cli *Client) parseGroupNotification(ctx context.Context, node *waBinary.Node) (any, []store.LIDMapping, []store.RedactedPhoneEntry, error) {
 	children := node.GetChildren()
 	if len(children) == 1 && children[0].Tag == "create" {
-		return cli.parseGroupCreate(node, &children[0])
+		return cli.parseGroupCreate(ctx, node, &children[0])
 	} else {
 		groupChange, lidPairs, err := cli.parseGroupChange(node)
 		if err != nil {
 			return nil, nil, nil, err
 		}
-		cli.updateGroupParticipantCache(groupChange)
+		cli.updateGroupParticipantCache(ctx, groupChange)
 		return groupChange, lidPairs, nil, nil
 	}
 }
@@ -1100,3 +1404,49 @@ func (cli *Client) SetGroupDescription(ctx context.Context, jid types.JID, descr
 	_, err := cli.sendGroupIQ(ctx, iqSet, jid, content)
 	return err
 }
+
+type respSetGroupParticipantLabelsEnabled struct {
+	Group struct {
+		ID types.JID `json:"id"`
+	} `json:"xwa2_group_update_property"`
+}
+
+// SetGroupParticipantLabelsEnabled enables or disables participant labels (member tags) in a group.
+//
+// When enabled, participants can set a label for themselves using [Client.SetGroupParticipantLabel].
+func (cli *Client) SetGroupParticipantLabelsEnabled(ctx context.Context, jid types.JID, enabled bool) error {
+	_, err := MexQuery[respSetGroupParticipantLabelsEnabled](ctx, cli, "GroupUpdateParticipantLabelEnabledMutation", map[string]any{
+		"group_id": jid.String(),
+		"properties": map[string]any{
+			"participant_label_enabled": enabled,
+		},
+	})
+	return err
+}
+
+type respSetGroupParticipantLabel struct {
+	Update struct {
+		Error *struct {
+			ResponseCode string `json:"response_code"`
+		} `json:"error"`
+		ParticipantLabel *types.GroupParticipantLabel `json:"participant_label"`
+	} `json:"xwa2_group_update_participant_property"`
+}
+
+// SetGroupParticipantLabel sets your own label (member tag) in a group. Use an empty string to clear the label.
+//
+// Participant labels must be enabled in the group, see [Client.SetGroupParticipantLabelsEnabled].
+func (cli *Client) SetGroupParticipantLabel(ctx context.Context, jid types.JID, label string) error {
+	resp, err := MexQuery[respSetGroupParticipantLabel](ctx, cli, "UpdateGroupParticipantLabelMutation", map[string]any{
+		"group_jid": jid.String(),
+		"participant_label": map[string]any{
+			"label": label,
+		},
+	})
+	if err != nil {
+		return err
+	} else if resp.Update.Error != nil && resp.Update.Error.ResponseCode != "" {
+		return fmt.Errorf("%w: %s", ErrParticipantLabelUpdateFailed, resp.Update.Error.ResponseCode)
+	}
+	return nil
+}
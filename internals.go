@@ -43,6 +43,14 @@ func (cli *Client) DangerousInternals() *DangerousInternalClient {
 type DangerousInfoQuery = infoQuery
 type DangerousInfoQueryType = infoQueryType
 
+func (int *DangerousInternalClient) HandleAlbumPart(evt *events.Message) {
+	int.c.handleAlbumPart(evt)
+}
+
+func (int *DangerousInternalClient) FinishAlbum(key albumKey) {
+	int.c.finishAlbum(key)
+}
+
 func (int *DangerousInternalClient) FetchAppState(ctx context.Context, name appstate.WAPatchName, fullSync, onlyIfNotSynced bool) ([]any, error) {
 	return int.c.fetchAppState(ctx, name, fullSync, onlyIfNotSynced)
 }
@@ -103,6 +111,22 @@ func (int *DangerousInternalClient) HandleCallEvent(ctx context.Context, node *w
 	int.c.handleCallEvent(ctx, node)
 }
 
+func (int *DangerousInternalClient) SendCatalogProductMutation(ctx context.Context, tag string, productNode waBinary.Node) (*types.CatalogProduct, error) {
+	return int.c.sendCatalogProductMutation(ctx, tag, productNode)
+}
+
+func (int *DangerousInternalClient) BuildCatalogProductNode(ctx context.Context, productID string, params CatalogProductParams) (waBinary.Node, error) {
+	return int.c.buildCatalogProductNode(ctx, productID, params)
+}
+
+func (int *DangerousInternalClient) GetBusinessOwner(owner types.JID) types.JID {
+	return int.c.getBusinessOwner(owner)
+}
+
+func (int *DangerousInternalClient) BuildCatalogImage(ctx context.Context, data []byte, imageURLs []string) (*waE2E.ImageMessage, error) {
+	return int.c.buildCatalogImage(ctx, data, imageURLs)
+}
+
 func (int *DangerousInternalClient) SetTransport(transport *http.Transport, opt SetProxyOptions) {
 	int.c.setTransport(transport, opt)
 }
@@ -191,6 +215,14 @@ func (int *DangerousInternalClient) SendUnifiedSession() {
 	int.c.sendUnifiedSession()
 }
 
+func (int *DangerousInternalClient) DoDiscoverContacts(ctx context.Context, phones []string, p ContactDiscoveryParams, query func(context.Context, []string) ([]types.IsOnWhatsAppResponse, error)) ([]ContactDiscoveryResult, error) {
+	return int.c.doDiscoverContacts(ctx, phones, p, query)
+}
+
+func (int *DangerousInternalClient) DiscoverContactChunk(ctx context.Context, chunk []string, params ContactDiscoveryParams, query func(context.Context, []string) ([]types.IsOnWhatsAppResponse, error)) ([]types.IsOnWhatsAppResponse, error) {
+	return int.c.discoverContactChunk(ctx, chunk, params, query)
+}
+
 func (int *DangerousInternalClient) HandleStreamError(ctx context.Context, node *waBinary.Node) {
 	int.c.handleStreamError(ctx, node)
 }
@@ -239,6 +271,18 @@ func (int *DangerousInternalClient) DownloadEncryptedMedia(ctx context.Context,
 	return int.c.downloadEncryptedMedia(ctx, url, checksum)
 }
 
+func (int *DangerousInternalClient) DownloadMediaRange(ctx context.Context, directPath string, encFileHash []byte, mmsType string, start, end int64) (data []byte, err error) {
+	return int.c.downloadMediaRange(ctx, directPath, encFileHash, mmsType, start, end)
+}
+
+func (int *DangerousInternalClient) DownloadMediaRangeWithRetries(ctx context.Context, url string, start, end int64) (data []byte, err error) {
+	return int.c.downloadMediaRangeWithRetries(ctx, url, start, end)
+}
+
+func (int *DangerousInternalClient) DoMediaRangeRequest(ctx context.Context, url string, start, end int64) ([]byte, error) {
+	return int.c.doMediaRangeRequest(ctx, url, start, end)
+}
+
 func (int *DangerousInternalClient) DownloadAndDecryptToFile(ctx context.Context, url string, mediaKey []byte, appInfo MediaType, fileEncSHA256, fileSHA256 []byte, file File) error {
 	return int.c.downloadAndDecryptToFile(ctx, url, mediaKey, appInfo, fileEncSHA256, fileSHA256, file)
 }
@@ -255,11 +299,23 @@ func (int *DangerousInternalClient) DownloadEncryptedMediaToFile(ctx context.Con
 	return int.c.downloadEncryptedMediaToFile(ctx, url, checksum, file)
 }
 
+func (int *DangerousInternalClient) ReuploadForwardedMedia(ctx context.Context, media DownloadableMessage) error {
+	return int.c.reuploadForwardedMedia(ctx, media)
+}
+
+func (int *DangerousInternalClient) IsMediaAvailable(ctx context.Context, media DownloadableMessage) (bool, error) {
+	return int.c.isMediaAvailable(ctx, media)
+}
+
 func (int *DangerousInternalClient) SendGroupIQ(ctx context.Context, iqType infoQueryType, jid types.JID, content waBinary.Node) (*waBinary.Node, error) {
 	return int.c.sendGroupIQ(ctx, iqType, jid, content)
 }
 
-func (int *DangerousInternalClient) CacheGroupInfo(groupInfo *types.GroupInfo, lock bool) ([]store.LIDMapping, []store.RedactedPhoneEntry) {
+func (int *DangerousInternalClient) GetDefaultSubGroup(ctx context.Context, community types.JID) types.JID {
+	return int.c.getDefaultSubGroup(ctx, community)
+}
+
+func (int *DangerousInternalClient) CacheGroupInfo(groupInfo *types.GroupInfo, lock bool) (*store.GroupMetadata, []store.LIDMapping, []store.RedactedPhoneEntry) {
 	return int.c.cacheGroupInfo(groupInfo, lock)
 }
 
@@ -267,7 +323,7 @@ func (int *DangerousInternalClient) GetGroupInfo(ctx context.Context, jid types.
 	return int.c.getGroupInfo(ctx, jid, lockParticipantCache)
 }
 
-func (int *DangerousInternalClient) GetCachedGroupData(ctx context.Context, jid types.JID) (*groupMetaCache, error) {
+func (int *DangerousInternalClient) GetCachedGroupData(ctx context.Context, jid types.JID) (*store.GroupMetadata, error) {
 	return int.c.getCachedGroupData(ctx, jid)
 }
 
@@ -275,20 +331,56 @@ func (int *DangerousInternalClient) ParseGroupNode(groupNode *waBinary.Node) (*t
 	return int.c.parseGroupNode(groupNode)
 }
 
-func (int *DangerousInternalClient) ParseGroupCreate(parentNode, node *waBinary.Node) (*events.JoinedGroup, []store.LIDMapping, []store.RedactedPhoneEntry, error) {
-	return int.c.parseGroupCreate(parentNode, node)
+func (int *DangerousInternalClient) ParseGroupCreate(ctx context.Context, parentNode, node *waBinary.Node) (*events.JoinedGroup, []store.LIDMapping, []store.RedactedPhoneEntry, error) {
+	return int.c.parseGroupCreate(ctx, parentNode, node)
 }
 
 func (int *DangerousInternalClient) ParseGroupChange(node *waBinary.Node) (*events.GroupInfo, []store.LIDMapping, error) {
 	return int.c.parseGroupChange(node)
 }
 
-func (int *DangerousInternalClient) UpdateGroupParticipantCache(evt *events.GroupInfo) {
-	int.c.updateGroupParticipantCache(evt)
+func (int *DangerousInternalClient) UpdateGroupParticipantCache(ctx context.Context, evt *events.GroupInfo) {
+	int.c.updateGroupParticipantCache(ctx, evt)
+}
+
+func (int *DangerousInternalClient) ParseGroupNotification(ctx context.Context, node *waBinary.Node) (any, []store.LIDMapping, []store.RedactedPhoneEntry, error) {
+	return int.c.parseGroupNotification(ctx, node)
+}
+
+func (int *DangerousInternalClient) DoBulkParticipantUpdate(ctx context.Context, participants []types.JID, params BulkParticipantParams, fn func([]types.JID) ([]types.GroupParticipant, error)) ([]BulkParticipantResult, error) {
+	return int.c.doBulkParticipantUpdate(ctx, participants, params, fn)
+}
+
+func (int *DangerousInternalClient) GetGroupCacheSize() int {
+	return int.c.getGroupCacheSize()
+}
+
+func (int *DangerousInternalClient) GetStoredGroupMetadata(ctx context.Context, jid types.JID) *store.GroupMetadata {
+	return int.c.getStoredGroupMetadata(ctx, jid)
+}
+
+func (int *DangerousInternalClient) PersistGroupMetadata(ctx context.Context, metas ...*store.GroupMetadata) {
+	int.c.persistGroupMetadata(ctx, metas...)
+}
+
+func (int *DangerousInternalClient) UnlockedInvalidateGroupCache(ctx context.Context, jid types.JID) {
+	int.c.unlockedInvalidateGroupCache(ctx, jid)
 }
 
-func (int *DangerousInternalClient) ParseGroupNotification(node *waBinary.Node) (any, []store.LIDMapping, []store.RedactedPhoneEntry, error) {
-	return int.c.parseGroupNotification(node)
+func (int *DangerousInternalClient) ShareGroupHistory(ctx context.Context, group types.JID, participants []types.GroupParticipant, extra UpdateGroupParticipantsExtra) error {
+	return int.c.shareGroupHistory(ctx, group, participants, extra)
+}
+
+func (int *DangerousInternalClient) IsGroupHistoryReceiver(bundle *waE2E.MessageHistoryBundle) bool {
+	return int.c.isGroupHistoryReceiver(bundle)
+}
+
+func (int *DangerousInternalClient) HandleGroupHistoryBundle(ctx context.Context, evt *events.Message) {
+	int.c.handleGroupHistoryBundle(ctx, evt)
+}
+
+func (int *DangerousInternalClient) SendGroupAddInvites(ctx context.Context, group types.JID, participants []types.GroupParticipant, caption string) error {
+	return int.c.sendGroupAddInvites(ctx, group, participants, caption)
 }
 
 func (int *DangerousInternalClient) DoHandshake(ctx context.Context, fs *socket.FrameSocket, ephemeralKP keys.KeyPair) (chan *waBinary.Node, error) {
@@ -311,6 +403,26 @@ func (int *DangerousInternalClient) QueryMediaConn(ctx context.Context) (*MediaC
 	return int.c.queryMediaConn(ctx)
 }
 
+func (int *DangerousInternalClient) WithMediaConn(ctx context.Context, fn func(*MediaConn) error) error {
+	return int.c.withMediaConn(ctx, fn)
+}
+
+func (int *DangerousInternalClient) GetSortedMediaHosts(mediaConn *MediaConn) []MediaConnHost {
+	return int.c.getSortedMediaHosts(mediaConn)
+}
+
+func (int *DangerousInternalClient) DoMediaRequest(req *http.Request) (*http.Response, error) {
+	return int.c.doMediaRequest(req)
+}
+
+func (int *DangerousInternalClient) GetMediaIPFallbackTransport() (*http.Transport, error) {
+	return int.c.getMediaIPFallbackTransport()
+}
+
+func (int *DangerousInternalClient) DoMediaRequestWithIPs(req *http.Request, hostname string, ips []MediaConnIP) (resp *http.Response, err error) {
+	return int.c.doMediaRequestWithIPs(req, hostname, ips)
+}
+
 func (int *DangerousInternalClient) HandleMediaRetryNotification(ctx context.Context, node *waBinary.Node) {
 	int.c.handleMediaRetryNotification(ctx, node)
 }
@@ -419,6 +531,22 @@ func (int *DangerousInternalClient) HandleDecryptedMessage(ctx context.Context,
 	return int.c.handleDecryptedMessage(ctx, info, msg, retryCount)
 }
 
+func (int *DangerousInternalClient) UsesWebMexQueryIDs() bool {
+	return int.c.usesWebMexQueryIDs()
+}
+
+func (int *DangerousInternalClient) GetMexQueryID(operation string) (string, error) {
+	return int.c.getMexQueryID(operation)
+}
+
+func (int *DangerousInternalClient) SendMexIQ(ctx context.Context, queryID string, variables any) (json.RawMessage, error) {
+	return int.c.sendMexIQ(ctx, queryID, variables)
+}
+
+func (int *DangerousInternalClient) SendMexIQWithOperation(ctx context.Context, queryID, operation string, variables any) (json.RawMessage, error) {
+	return int.c.sendMexIQWithOperation(ctx, queryID, operation, variables)
+}
+
 func (int *DangerousInternalClient) DecryptMsgSecret(ctx context.Context, msg *events.Message, useCase MsgSecretType, encrypted messageEncryptedSecret, origMsgKey *waCommon.MessageKey) ([]byte, error) {
 	return int.c.decryptMsgSecret(ctx, msg, useCase, encrypted, origMsgKey)
 }
@@ -431,10 +559,6 @@ func (int *DangerousInternalClient) DecryptBotMessage(ctx context.Context, messa
 	return int.c.decryptBotMessage(ctx, messageSecret, msMsg, messageID, targetSenderJID, info)
 }
 
-func (int *DangerousInternalClient) SendMexIQ(ctx context.Context, queryID string, variables any) (json.RawMessage, error) {
-	return int.c.sendMexIQ(ctx, queryID, variables)
-}
-
 func (int *DangerousInternalClient) GetNewsletterInfo(ctx context.Context, input map[string]any, fetchViewerMeta bool) (*types.NewsletterMetadata, error) {
 	return int.c.getNewsletterInfo(ctx, input, fetchViewerMeta)
 }
@@ -483,6 +607,10 @@ func (int *DangerousInternalClient) HandleNewsletterNotification(ctx context.Con
 	int.c.handleNewsletterNotification(ctx, node)
 }
 
+func (int *DangerousInternalClient) ApplyMexNotification(ctx context.Context, evt any) {
+	int.c.applyMexNotification(ctx, evt)
+}
+
 func (int *DangerousInternalClient) HandleMexNotification(ctx context.Context, node *waBinary.Node) {
 	int.c.handleMexNotification(ctx, node)
 }
@@ -575,6 +703,38 @@ func (int *DangerousInternalClient) HandlePresence(ctx context.Context, node *wa
 	int.c.handlePresence(ctx, node)
 }
 
+func (int *DangerousInternalClient) SendPresenceSubscribe(ctx context.Context, jid types.JID, privacyToken []byte) error {
+	return int.c.sendPresenceSubscribe(ctx, jid, privacyToken)
+}
+
+func (int *DangerousInternalClient) ResubscribePresences(ctx context.Context) {
+	int.c.resubscribePresences(ctx)
+}
+
+func (int *DangerousInternalClient) CachePresence(evt *events.Presence) {
+	int.c.cachePresence(evt)
+}
+
+func (int *DangerousInternalClient) GetChatPresenceTimeout() time.Duration {
+	return int.c.getChatPresenceTimeout()
+}
+
+func (int *DangerousInternalClient) UpdateChatTyping(source types.MessageSource, state types.ChatPresence, media types.ChatPresenceMedia) {
+	int.c.updateChatTyping(source, state, media)
+}
+
+func (int *DangerousInternalClient) ClearChatTyping(chat, sender types.JID) {
+	int.c.clearChatTyping(chat, sender)
+}
+
+func (int *DangerousInternalClient) DoPrivacyListUpdate(ctx context.Context, name types.PrivacySettingType, value types.PrivacySetting, getChanges func([]types.JID) ([]types.JID, []types.JID)) (*types.PrivacyList, error) {
+	return int.c.doPrivacyListUpdate(ctx, name, value, getChanges)
+}
+
+func (int *DangerousInternalClient) SendPrivacyListUpdate(ctx context.Context, list *types.PrivacyList, add, remove []types.JID) (*types.PrivacyList, error) {
+	return int.c.sendPrivacyListUpdate(ctx, list, add, remove)
+}
+
 func (int *DangerousInternalClient) ParsePrivacySettings(privacyNode *waBinary.Node, settings *types.PrivacySettings) *events.PrivacySettings {
 	return int.c.parsePrivacySettings(privacyNode, settings)
 }
@@ -803,10 +963,34 @@ func (int *DangerousInternalClient) IssuePrivacyToken(ctx context.Context, jid t
 	return int.c.issuePrivacyToken(ctx, jid, timestamp)
 }
 
+func (int *DangerousInternalClient) CacheTextStatuses(statuses ...*types.TextStatus) {
+	int.c.cacheTextStatuses(statuses...)
+}
+
+func (int *DangerousInternalClient) RemoveTypingSession(ts *TypingSession) {
+	int.c.removeTypingSession(ts)
+}
+
+func (int *DangerousInternalClient) EndTypingSession(chat types.JID, sendPaused bool) {
+	int.c.endTypingSession(chat, sendPaused)
+}
+
 func (int *DangerousInternalClient) RawUpload(ctx context.Context, dataToUpload io.Reader, uploadSize uint64, fileHash []byte, appInfo MediaType, newsletter bool, resp *UploadResponse) error {
 	return int.c.rawUpload(ctx, dataToUpload, uploadSize, fileHash, appInfo, newsletter, resp)
 }
 
+func (int *DangerousInternalClient) GetMediaUploadPath(appInfo MediaType, newsletter bool) string {
+	return int.c.getMediaUploadPath(appInfo, newsletter)
+}
+
+func (int *DangerousInternalClient) UploadToPath(ctx context.Context, dataToUpload io.Reader, uploadSize uint64, fileHash []byte, uploadPath string, resp *UploadResponse) error {
+	return int.c.uploadToPath(ctx, dataToUpload, uploadSize, fileHash, uploadPath, resp)
+}
+
+func (int *DangerousInternalClient) DoRawUpload(ctx context.Context, mediaConn *MediaConn, dataToUpload io.Reader, uploadSize uint64, fileHash []byte, uploadPath string, resp *UploadResponse) error {
+	return int.c.doRawUpload(ctx, mediaConn, dataToUpload, uploadSize, fileHash, uploadPath, resp)
+}
+
 func (int *DangerousInternalClient) ParseBusinessProfile(node *waBinary.Node) (*types.BusinessProfile, error) {
 	return int.c.parseBusinessProfile(node)
 }
@@ -835,6 +1019,18 @@ func (int *DangerousInternalClient) Usync(ctx context.Context, jids []types.JID,
 	return int.c.usync(ctx, jids, mode, context, query, extra...)
 }
 
+func (int *DangerousInternalClient) SendUsync(ctx context.Context, mode, context string, query, userList []waBinary.Node) (*waBinary.Node, error) {
+	return int.c.sendUsync(ctx, mode, context, query, userList)
+}
+
 func (int *DangerousInternalClient) ParseBlocklist(node *waBinary.Node) *types.Blocklist {
 	return int.c.parseBlocklist(node)
 }
+
+func (int *DangerousInternalClient) SetUsername(ctx context.Context, username string) (string, error) {
+	return int.c.setUsername(ctx, username)
+}
+
+func (int *DangerousInternalClient) StoreUsernameMapping(ctx context.Context, lid types.JID, username string) {
+	int.c.storeUsernameMapping(ctx, lid, username)
+}
@@ -100,12 +100,15 @@ var writef func(format string, args ...any)
 func main() {
 	fset := token.NewFileSet()
 	fileNames := []string{
-		"appstate.go", "armadillomessage.go", "broadcast.go", "call.go", "client.go",
-		"connectionevents.go", "cstoken.go", "download.go", "download-to-file.go", "group.go", "handshake.go",
-		"keepalive.go", "mediaconn.go", "mediaretry.go", "message.go", "msgsecret.go",
-		"newsletter.go", "notification.go", "pair-code.go", "pair.go", "pair-passkey.go", "prekeys.go",
-		"presence.go", "privacysettings.go", "push.go", "qrchan.go", "receipt.go", "reportingtoken.go",
-		"request.go", "retry.go", "send.go", "sendfb.go", "tctoken.go", "upload.go", "user.go",
+		"album.go", "appstate.go", "armadillomessage.go", "broadcast.go", "call.go", "catalog.go",
+		"catalog-message.go", "client.go", "community.go", "contactdiscovery.go", "connectionevents.go", "cstoken.go",
+		"displayname.go", "download.go", "download-range.go", "download-to-file.go", "forward.go", "group.go",
+		"group-bulk.go", "group-cache.go", "group-history.go", "group-invite.go", "handshake.go", "keepalive.go",
+		"mediaconn.go", "mediaretry.go", "message.go", "mex.go", "msgsecret.go", "newsletter.go",
+		"newsletter-admin.go", "newsletter-directory.go", "notification.go", "pair-code.go", "pair.go",
+		"pair-passkey.go", "prekeys.go", "presence.go", "presence-tracker.go", "privacysettings.go", "push.go",
+		"qrchan.go", "receipt.go", "reportingtoken.go", "request.go", "retry.go", "send.go", "sendfb.go",
+		"tctoken.go", "textstatus.go", "typing.go", "upload.go", "user.go", "username.go",
 	}
 	files := make([]*ast.File, len(fileNames))
 	for i, name := range fileNames {
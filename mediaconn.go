@@ -7,23 +7,31 @@
 package whatsmeow
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	waBinary "go.mau.fi/whatsmeow/binary"
 	"go.mau.fi/whatsmeow/types"
 )
 
-//type MediaConnIP struct {
-//	IP4 net.IP
-//	IP6 net.IP
-//}
+// MediaConnIP contains the direct IP addresses of a media host, which are used if DNS resolution fails.
+type MediaConnIP struct {
+	IP4 net.IP
+	IP6 net.IP
+}
 
 // MediaConnHost represents a single host to download media from.
 type MediaConnHost struct {
 	Hostname string
-	//IPs      []MediaConnIP
+	IPs      []MediaConnIP
 }
 
 // MediaConn contains a list of WhatsApp servers from which attachments can be downloaded from.
@@ -41,13 +49,36 @@ func (mc *MediaConn) Expiry() time.Time {
 	return mc.FetchedAt.Add(time.Duration(mc.TTL) * time.Second)
 }
 
+// AuthExpiry returns the time when the auth token in the MediaConn expires.
+// If the server didn't specify an auth TTL, this is the same as Expiry.
+func (mc *MediaConn) AuthExpiry() time.Time {
+	if mc.AuthTTL <= 0 {
+		return mc.Expiry()
+	}
+	return mc.FetchedAt.Add(time.Duration(mc.AuthTTL) * time.Second)
+}
+
+func (mc *MediaConn) isExpired() bool {
+	now := time.Now()
+	return now.After(mc.Expiry()) || now.After(mc.AuthExpiry())
+}
+
+func (mc *MediaConn) getHostIPs(hostname string) []MediaConnIP {
+	for _, host := range mc.Hosts {
+		if host.Hostname == hostname {
+			return host.IPs
+		}
+	}
+	return nil
+}
+
 func (cli *Client) refreshMediaConn(ctx context.Context, force bool) (*MediaConn, error) {
 	if cli == nil {
 		return nil, ErrClientIsNil
 	}
 	cli.mediaConnLock.Lock()
 	defer cli.mediaConnLock.Unlock()
-	if cli.mediaConnCache == nil || force || time.Now().After(cli.mediaConnCache.Expiry()) {
+	if cli.mediaConnCache == nil || force || cli.mediaConnCache.isExpired() {
 		var err error
 		cli.mediaConnCache, err = cli.queryMediaConn(ctx)
 		if err != nil {
@@ -86,12 +117,322 @@ func (cli *Client) queryMediaConn(ctx context.Context) (*MediaConn, error) {
 			continue
 		}
 		cag := child.AttrGetter()
-		mc.Hosts = append(mc.Hosts, MediaConnHost{
+		host := MediaConnHost{
 			Hostname: cag.String("hostname"),
-		})
+		}
 		if !cag.OK() {
 			return nil, fmt.Errorf("failed to parse media connection host: %+v", ag.Errors)
 		}
+		for _, ipNode := range child.GetChildren() {
+			ip := net.ParseIP(ipNode.AttrGetter().OptionalString("ip"))
+			if ip == nil {
+				continue
+			}
+			switch ipNode.Tag {
+			case "ip4":
+				host.IPs = append(host.IPs, MediaConnIP{IP4: ip})
+			case "ip6":
+				host.IPs = append(host.IPs, MediaConnIP{IP6: ip})
+			}
+		}
+		mc.Hosts = append(mc.Hosts, host)
 	}
 	return &mc, nil
 }
+
+// MediaHostStats contains the health statistics of a single media host.
+type MediaHostStats struct {
+	Hostname string
+
+	Successes int
+	Failures  int
+	// ConsecutiveFailures is the number of failed requests since the last successful one.
+	ConsecutiveFailures int
+	// FailureRate is an exponentially weighted moving average of failed requests (0-1).
+	FailureRate float64
+	// Latency is an exponentially weighted moving average of the time it took to receive response headers.
+	Latency time.Duration
+
+	LastSuccess time.Time
+	LastFailure time.Time
+	LastError   string
+	// DNSFallbacks is the number of requests that were sent directly to an IP address because DNS resolution failed.
+	DNSFallbacks int
+}
+
+const (
+	mediaHostStatsWeight     = 0.2
+	mediaHostCooldown        = 30 * time.Second
+	mediaHostMaxCooldown     = 10 * time.Minute
+	mediaHostCooldownMaxStep = 5
+)
+
+// cooldownUntil returns the time until which the host should be avoided due to recent consecutive failures.
+func (mhs *MediaHostStats) cooldownUntil() time.Time {
+	if mhs.ConsecutiveFailures == 0 {
+		return time.Time{}
+	}
+	cooldown := min(mediaHostCooldown<<min(mhs.ConsecutiveFailures-1, mediaHostCooldownMaxStep), mediaHostMaxCooldown)
+	return mhs.LastFailure.Add(cooldown)
+}
+
+type mediaHostTracker struct {
+	stats map[string]*MediaHostStats
+	lock  sync.RWMutex
+}
+
+func newMediaHostTracker() *mediaHostTracker {
+	return &mediaHostTracker{stats: make(map[string]*MediaHostStats)}
+}
+
+func (mht *mediaHostTracker) record(hostname string, latency time.Duration, err error, dnsFallback bool) {
+	mht.lock.Lock()
+	defer mht.lock.Unlock()
+	stats, ok := mht.stats[hostname]
+	if !ok {
+		stats = &MediaHostStats{Hostname: hostname}
+		mht.stats[hostname] = stats
+	}
+	if dnsFallback {
+		stats.DNSFallbacks++
+	}
+	now := time.Now()
+	if err != nil {
+		stats.Failures++
+		stats.ConsecutiveFailures++
+		stats.FailureRate = stats.FailureRate*(1-mediaHostStatsWeight) + mediaHostStatsWeight
+		stats.LastFailure = now
+		stats.LastError = err.Error()
+		return
+	}
+	stats.Successes++
+	stats.ConsecutiveFailures = 0
+	stats.FailureRate *= 1 - mediaHostStatsWeight
+	stats.LastSuccess = now
+	if stats.Latency == 0 {
+		stats.Latency = latency
+	} else {
+		stats.Latency = time.Duration(float64(stats.Latency)*(1-mediaHostStatsWeight) + float64(latency)*mediaHostStatsWeight)
+	}
+}
+
+// mediaHostRank is the sort key of a media host. Lower values are better.
+type mediaHostRank struct {
+	cooling bool
+	// The failure rate in steps of 5%, so that tiny differences don't override latency
+	failureBucket int
+	// Zero if the latency is unknown, which sorts after all known latencies
+	latency time.Duration
+}
+
+const mediaHostFailureBucketSize = 0.05
+
+func (mht *mediaHostTracker) rank(hostname string, now time.Time) mediaHostRank {
+	stats := mht.stats[hostname]
+	if stats == nil {
+		// Hosts that haven't been used yet are assumed to be healthy
+		return mediaHostRank{}
+	}
+	return mediaHostRank{
+		cooling:       now.Before(stats.cooldownUntil()),
+		failureBucket: int(stats.FailureRate / mediaHostFailureBucketSize),
+		latency:       stats.Latency,
+	}
+}
+
+func (mhr mediaHostRank) compare(other mediaHostRank) int {
+	if mhr.cooling != other.cooling {
+		if mhr.cooling {
+			return 1
+		}
+		return -1
+	} else if mhr.failureBucket != other.failureBucket {
+		return cmp.Compare(mhr.failureBucket, other.failureBucket)
+	} else if (mhr.latency == 0) != (other.latency == 0) {
+		if mhr.latency == 0 {
+			return 1
+		}
+		return -1
+	}
+	return cmp.Compare(mhr.latency, other.latency)
+}
+
+// sort returns the given hosts ordered from healthiest to least healthy.
+//
+// Hosts that are cooling down after consecutive failures are moved to the end, other hosts are sorted by
+// failure rate and then latency. Hosts without any statistics are treated as healthy with unknown latency.
+// Hosts with equal rank keep the order the server sent them in.
+func (mht *mediaHostTracker) sort(hosts []MediaConnHost) []MediaConnHost {
+	mht.lock.RLock()
+	defer mht.lock.RUnlock()
+	now := time.Now()
+	ranks := make(map[string]mediaHostRank, len(hosts))
+	for _, host := range hosts {
+		ranks[host.Hostname] = mht.rank(host.Hostname, now)
+	}
+	sorted := slices.Clone(hosts)
+	slices.SortStableFunc(sorted, func(a, b MediaConnHost) int {
+		return ranks[a.Hostname].compare(ranks[b.Hostname])
+	})
+	return sorted
+}
+
+func (mht *mediaHostTracker) snapshot() []MediaHostStats {
+	mht.lock.RLock()
+	defer mht.lock.RUnlock()
+	output := make([]MediaHostStats, 0, len(mht.stats))
+	for _, stats := range mht.stats {
+		output = append(output, *stats)
+	}
+	slices.SortFunc(output, func(a, b MediaHostStats) int {
+		return strings.Compare(a.Hostname, b.Hostname)
+	})
+	return output
+}
+
+// MediaHostStats returns the health statistics of all media hosts that have been used by this client.
+//
+// Media uploads and downloads try hosts in order of these statistics, so that a single broken host
+// doesn't slow down every request.
+func (cli *Client) MediaHostStats() []MediaHostStats {
+	if cli == nil {
+		return nil
+	}
+	return cli.mediaHosts.snapshot()
+}
+
+// withMediaConn calls fn with the current media connection. If the media server rejects the request as unauthorized,
+// the media connection is refreshed and fn is called again.
+func (cli *Client) withMediaConn(ctx context.Context, fn func(mediaConn *MediaConn) error) error {
+	mediaConn, err := cli.refreshMediaConn(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to refresh media connections: %w", err)
+	}
+	err = fn(mediaConn)
+	if !isMediaAuthExpired(err) {
+		return err
+	}
+	cli.Log.Debugf("Media server rejected auth, refreshing media connection and retrying")
+	mediaConn, err = cli.refreshMediaConn(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to refresh media connections: %w", err)
+	}
+	return fn(mediaConn)
+}
+
+func isMediaAuthExpired(err error) bool {
+	var httpErr DownloadHTTPError
+	return errors.Is(err, errMediaAuthExpired) ||
+		(errors.As(err, &httpErr) && httpErr.Response.StatusCode == http.StatusUnauthorized)
+}
+
+// getSortedMediaHosts returns the hosts of the given media connection ordered from healthiest to least healthy.
+func (cli *Client) getSortedMediaHosts(mediaConn *MediaConn) []MediaConnHost {
+	return cli.mediaHosts.sort(mediaConn.Hosts)
+}
+
+// doMediaRequest sends a HTTP request to a media host, records the result in the host statistics and
+// retries directly with the host's IP addresses if DNS resolution fails.
+func (cli *Client) doMediaRequest(req *http.Request) (*http.Response, error) {
+	hostname := req.URL.Hostname()
+	start := time.Now()
+	resp, err := cli.mediaHTTP.Do(req)
+	var dnsErr *net.DNSError
+	usedFallback := false
+	if errors.As(err, &dnsErr) {
+		cli.mediaConnLock.Lock()
+		mediaConn := cli.mediaConnCache
+		cli.mediaConnLock.Unlock()
+		if mediaConn != nil {
+			if ips := mediaConn.getHostIPs(hostname); len(ips) > 0 {
+				cli.Log.Warnf("Failed to resolve media host %s: %v, trying with direct IPs", hostname, dnsErr)
+				usedFallback = true
+				resp, err = cli.doMediaRequestWithIPs(req, hostname, ips)
+			}
+		}
+	}
+	var hostErr error
+	if err != nil {
+		hostErr = err
+	} else if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		// Other error statuses (like 404) are about the file rather than the host, so they're not counted as failures
+		hostErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	if !errors.Is(err, context.Canceled) {
+		cli.mediaHosts.record(hostname, time.Since(start), hostErr, usedFallback)
+	}
+	return resp, err
+}
+
+type mediaFallbackIPKey struct{}
+
+type mediaFallbackIP struct {
+	hostname string
+	addr     net.IP
+}
+
+// getMediaIPFallbackTransport returns the transport used for direct IP fallback requests.
+// It's a clone of the media HTTP client's transport which connects to the IP address stored in the request context
+// instead of resolving the hostname. The transport is reused until the media HTTP client's transport is changed.
+func (cli *Client) getMediaIPFallbackTransport() (*http.Transport, error) {
+	baseTransport, ok := cli.mediaHTTP.Transport.(*http.Transport)
+	if !ok && cli.mediaHTTP.Transport != nil {
+		return nil, fmt.Errorf("can't use direct IP fallback with custom HTTP transport %T", cli.mediaHTTP.Transport)
+	} else if baseTransport == nil {
+		baseTransport = http.DefaultTransport.(*http.Transport)
+	}
+	cli.mediaIPFallbackLock.Lock()
+	defer cli.mediaIPFallbackLock.Unlock()
+	if cli.mediaIPFallbackTransport != nil && cli.mediaIPFallbackBase == baseTransport {
+		return cli.mediaIPFallbackTransport, nil
+	} else if cli.mediaIPFallbackTransport != nil {
+		cli.mediaIPFallbackTransport.CloseIdleConnections()
+	}
+	transport := baseTransport.Clone()
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if target, ok := ctx.Value(mediaFallbackIPKey{}).(*mediaFallbackIP); ok {
+			if host, port, err := net.SplitHostPort(addr); err == nil && host == target.hostname {
+				addr = net.JoinHostPort(target.addr.String(), port)
+			}
+		}
+		return dial(ctx, network, addr)
+	}
+	cli.mediaIPFallbackTransport = transport
+	cli.mediaIPFallbackBase = baseTransport
+	return transport, nil
+}
+
+func (cli *Client) doMediaRequestWithIPs(req *http.Request, hostname string, ips []MediaConnIP) (resp *http.Response, err error) {
+	transport, err := cli.getMediaIPFallbackTransport()
+	if err != nil {
+		return nil, err
+	}
+	err = fmt.Errorf("no usable IPs for %s", hostname)
+	client := &http.Client{Transport: transport, Timeout: cli.mediaHTTP.Timeout}
+	for _, ip := range ips {
+		for _, addr := range []net.IP{ip.IP4, ip.IP6} {
+			if addr == nil {
+				continue
+			}
+			if req.GetBody != nil {
+				req.Body, err = req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+			} else if req.Body != nil && req.Body != http.NoBody {
+				return nil, fmt.Errorf("can't retry request to %s with non-replayable body", hostname)
+			}
+			// The URL keeps the hostname, so TLS verification and connection pooling work like with DNS
+			ipReq := req.Clone(context.WithValue(req.Context(), mediaFallbackIPKey{}, &mediaFallbackIP{hostname: hostname, addr: addr}))
+			resp, err = client.Do(ipReq)
+			if err == nil {
+				return resp, nil
+			}
+		}
+	}
+	return nil, err
+}
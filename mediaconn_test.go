@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func hostnames(hosts []MediaConnHost) []string {
+	names := make([]string, len(hosts))
+	for i, host := range hosts {
+		names[i] = host.Hostname
+	}
+	return names
+}
+
+func TestMediaHostTracker_Sort(t *testing.T) {
+	mht := newMediaHostTracker()
+	mht.record("cooling", time.Second, errors.New("timeout"), false)
+	mht.record("healthy", 100*time.Millisecond, nil, false)
+	mht.record("slow", 500*time.Millisecond, nil, false)
+	mht.record("flaky", 50*time.Millisecond, nil, false)
+	mht.record("flaky", 50*time.Millisecond, errors.New("reset"), false)
+	mht.record("flaky", 50*time.Millisecond, nil, false)
+
+	tests := []struct {
+		input    []string
+		expected []string
+	}{
+		{[]string{"cooling", "unknown", "healthy"}, []string{"healthy", "unknown", "cooling"}},
+		{[]string{"unknown", "cooling", "healthy"}, []string{"healthy", "unknown", "cooling"}},
+		{[]string{"slow", "healthy"}, []string{"healthy", "slow"}},
+		{[]string{"flaky", "slow", "unknown"}, []string{"slow", "unknown", "flaky"}},
+		{[]string{"unknown2", "unknown"}, []string{"unknown2", "unknown"}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.input), func(t *testing.T) {
+			hosts := make([]MediaConnHost, len(test.input))
+			for i, name := range test.input {
+				hosts[i].Hostname = name
+			}
+			if sorted := hostnames(mht.sort(hosts)); !slices.Equal(sorted, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, sorted)
+			}
+		})
+	}
+}
+
+func TestMediaHostTracker_SortIsConsistent(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	mht := newMediaHostTracker()
+	hosts := make([]MediaConnHost, 30)
+	for i := range hosts {
+		hosts[i].Hostname = fmt.Sprintf("host%d", i)
+		// Leave some hosts without statistics
+		for j := rng.IntN(5); j > 0; j-- {
+			var err error
+			if rng.IntN(3) == 0 {
+				err = errors.New("failed")
+			}
+			mht.record(hosts[i].Hostname, time.Duration(rng.IntN(1000))*time.Millisecond, err, false)
+		}
+	}
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		rng.Shuffle(len(hosts), func(a, b int) { hosts[a], hosts[b] = hosts[b], hosts[a] })
+		sorted := mht.sort(hosts)
+		for j := 1; j < len(sorted); j++ {
+			prev, cur := mht.rank(sorted[j-1].Hostname, now), mht.rank(sorted[j].Hostname, now)
+			if prev.compare(cur) > 0 {
+				t.Fatalf("%s (%+v) sorted before %s (%+v)", sorted[j-1].Hostname, prev, sorted[j].Hostname, cur)
+			}
+		}
+	}
+}
+
+func TestDoMediaRequestWithIPs(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || r.TLS.ServerName != "example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	cli := &Client{mediaHTTP: srv.Client()}
+	ips := []MediaConnIP{{IP4: net.IPv4(127, 0, 0, 1)}}
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, "https://"+net.JoinHostPort("example.com", port)+"/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := cli.doMediaRequestWithIPs(req, "example.com", ips)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status %d", resp.StatusCode)
+		}
+	}
+	transport := cli.mediaIPFallbackTransport
+	if _, err := cli.getMediaIPFallbackTransport(); err != nil {
+		t.Fatal(err)
+	} else if cli.mediaIPFallbackTransport != transport {
+		t.Error("fallback transport wasn't reused")
+	}
+	cli.mediaHTTP = &http.Client{Transport: srv.Client().Transport.(*http.Transport).Clone()}
+	if newTransport, err := cli.getMediaIPFallbackTransport(); err != nil {
+		t.Fatal(err)
+	} else if newTransport == transport {
+		t.Error("fallback transport wasn't rebuilt after changing the media HTTP client")
+	}
+}
@@ -0,0 +1,466 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+// MediaUploader is the interface used by the media message builders to upload files.
+//
+// [Client] implements this interface. Other implementations can be used to build messages without a connection,
+// e.g. in tests.
+type MediaUploader interface {
+	Upload(ctx context.Context, plaintext []byte, appInfo MediaType) (UploadResponse, error)
+}
+
+var _ MediaUploader = (*Client)(nil)
+
+// Thumbnail sizes used by the media message builders.
+const (
+	// InlineThumbnailSize is the maximum width and height of the JPEG thumbnail that is embedded directly
+	// in media messages (the JPEGThumbnail field).
+	InlineThumbnailSize = 72
+	// LinkThumbnailSize is the maximum width and height of high-quality link preview thumbnails,
+	// which are uploaded separately as MediaLinkThumbnail.
+	LinkThumbnailSize = 512
+
+	thumbnailJPEGQuality = 75
+)
+
+var (
+	ErrNoMediaData            = errors.New("no media data provided")
+	ErrUnsupportedImageFormat = errors.New("unsupported image format")
+)
+
+// MediaMessageParams contains the parameters for [BuildMediaMessage].
+type MediaMessageParams struct {
+	// The file to send. Exactly one of Data or Reader should be set.
+	// If Reader is used, the whole file is read into memory, as it needs to be analyzed before uploading.
+	Data   []byte
+	Reader io.Reader
+
+	// The mime type of the file. If empty, it's detected from the file contents.
+	MimeType string
+	// If true, the file is always sent as a document, even if it's an image, video or audio file.
+	AsDocument bool
+
+	Caption string
+	// The file name. Only used for documents. If the mime type is not set or detected, the extension will also be
+	// used to guess the mime type.
+	FileName string
+
+	// The duration of video and audio files. This can't be detected automatically.
+	Duration time.Duration
+	// The dimensions of video files. Image dimensions are detected automatically.
+	Width, Height uint32
+	// An image to use for the thumbnail of videos, or to override the generated thumbnail of images.
+	// The image will be resized to the appropriate size.
+	Thumbnail []byte
+
+	// If true, audio files are sent as voice messages.
+	PTT bool
+	// If true, videos are sent as GIFs (i.e. muted and looping).
+	GIFPlayback bool
+	ViewOnce    bool
+
+	ContextInfo *waE2E.ContextInfo
+}
+
+// BuildMediaMessage uploads the given file and builds a message containing it.
+// The built message can be sent normally using [Client.SendMessage].
+//
+// The message type (image, video, audio or document) is chosen based on the mime type. For images, the dimensions
+// are read from the file and a JPEG thumbnail is generated. Decoding supports JPEG, PNG and GIF by default,
+// other formats can be enabled by importing the appropriate decoder package (e.g. golang.org/x/image/webp).
+//
+//	msg, err := cli.BuildMediaMessage(ctx, whatsmeow.MediaMessageParams{
+//		Data:    imageBytes,
+//		Caption: "Hello, world!",
+//	})
+//	// handle error
+//	_, err = cli.SendMessage(ctx, chat, msg)
+func (cli *Client) BuildMediaMessage(ctx context.Context, params MediaMessageParams) (*waE2E.Message, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	return BuildMediaMessage(ctx, cli, params)
+}
+
+// BuildMediaMessage uploads the given file with the given uploader and builds a message containing it.
+//
+// See [Client.BuildMediaMessage] for more info.
+func BuildMediaMessage(ctx context.Context, uploader MediaUploader, params MediaMessageParams) (*waE2E.Message, error) {
+	data := params.Data
+	if data == nil && params.Reader != nil {
+		var err error
+		data, err = io.ReadAll(params.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read media: %w", err)
+		}
+	}
+	if len(data) == 0 {
+		return nil, ErrNoMediaData
+	}
+	mimeType := params.MimeType
+	if mimeType == "" {
+		mimeType = DetectMimeType(data, params.FileName)
+	}
+	mediaType := MediaDocument
+	if !params.AsDocument {
+		switch strings.SplitN(mimeType, "/", 2)[0] {
+		case "image":
+			if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+				mediaType = MediaImage
+			}
+		case "video":
+			mediaType = MediaVideo
+		case "audio":
+			mediaType = MediaAudio
+		}
+	}
+
+	msg := &waE2E.Message{}
+	switch mediaType {
+	case MediaImage:
+		img, err := buildImageMessage(data, &params)
+		if err != nil {
+			return nil, err
+		}
+		msg.ImageMessage = img
+	case MediaVideo:
+		msg.VideoMessage = &waE2E.VideoMessage{
+			Caption:     nonEmptyString(params.Caption),
+			GifPlayback: nonFalseBool(params.GIFPlayback),
+			Seconds:     nonZeroSeconds(params.Duration),
+			Width:       nonZeroUint32(params.Width),
+			Height:      nonZeroUint32(params.Height),
+			ViewOnce:    nonFalseBool(params.ViewOnce),
+			ContextInfo: params.ContextInfo,
+		}
+		if params.Thumbnail != nil {
+			thumbnail, _, _, err := GenerateJPEGThumbnail(params.Thumbnail, InlineThumbnailSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate thumbnail: %w", err)
+			}
+			msg.VideoMessage.JPEGThumbnail = thumbnail
+		}
+	case MediaAudio:
+		msg.AudioMessage = &waE2E.AudioMessage{
+			PTT:         nonFalseBool(params.PTT),
+			Seconds:     nonZeroSeconds(params.Duration),
+			ViewOnce:    nonFalseBool(params.ViewOnce),
+			ContextInfo: params.ContextInfo,
+		}
+	default:
+		msg.DocumentMessage = &waE2E.DocumentMessage{
+			Caption:     nonEmptyString(params.Caption),
+			FileName:    nonEmptyString(params.FileName),
+			Title:       nonEmptyString(params.FileName),
+			ContextInfo: params.ContextInfo,
+		}
+		thumbSource := params.Thumbnail
+		if thumbSource == nil && strings.HasPrefix(mimeType, "image/") {
+			thumbSource = data
+		}
+		if thumbSource != nil {
+			// Thumbnails are optional for documents, so don't fail if the image can't be decoded
+			thumbnail, width, height, err := GenerateJPEGThumbnail(thumbSource, InlineThumbnailSize)
+			if err == nil {
+				msg.DocumentMessage.JPEGThumbnail = thumbnail
+				msg.DocumentMessage.ThumbnailWidth = proto.Uint32(uint32(width))
+				msg.DocumentMessage.ThumbnailHeight = proto.Uint32(uint32(height))
+			}
+		}
+	}
+
+	resp, err := uploader.Upload(ctx, data, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload media: %w", err)
+	}
+	fillUploadedMedia(msg, mimeType, &resp)
+	return msg, nil
+}
+
+func buildImageMessage(data []byte, params *MediaMessageParams) (*waE2E.ImageMessage, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	var thumbnail []byte
+	if params.Thumbnail != nil {
+		thumbnail, _, _, err = GenerateJPEGThumbnail(params.Thumbnail, InlineThumbnailSize)
+	} else {
+		thumbnail, _, _, err = encodeJPEGThumbnail(img, InlineThumbnailSize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate thumbnail: %w", err)
+	}
+	bounds := img.Bounds()
+	return &waE2E.ImageMessage{
+		Caption:       nonEmptyString(params.Caption),
+		Width:         proto.Uint32(uint32(bounds.Dx())),
+		Height:        proto.Uint32(uint32(bounds.Dy())),
+		JPEGThumbnail: thumbnail,
+		ViewOnce:      nonFalseBool(params.ViewOnce),
+		ContextInfo:   params.ContextInfo,
+	}, nil
+}
+
+func fillUploadedMedia(msg *waE2E.Message, mimeType string, resp *UploadResponse) {
+	switch {
+	case msg.ImageMessage != nil:
+		msg.ImageMessage.Mimetype = proto.String(mimeType)
+		applyUploadResponse(msg.ImageMessage, resp)
+	case msg.VideoMessage != nil:
+		msg.VideoMessage.Mimetype = proto.String(mimeType)
+		applyUploadResponse(msg.VideoMessage, resp)
+	case msg.AudioMessage != nil:
+		msg.AudioMessage.Mimetype = proto.String(mimeType)
+		applyUploadResponse(msg.AudioMessage, resp)
+	case msg.DocumentMessage != nil:
+		msg.DocumentMessage.Mimetype = proto.String(mimeType)
+		applyUploadResponse(msg.DocumentMessage, resp)
+	}
+}
+
+// applyUploadResponse copies the fields of an upload response into the given media message.
+// Uploaded thumbnails are removed, as they're encrypted with the old media key.
+func applyUploadResponse(media DownloadableMessage, resp *UploadResponse) {
+	mediaKeyTimestamp := proto.Int64(time.Now().Unix())
+	switch typedMedia := media.(type) {
+	case *waE2E.ImageMessage:
+		typedMedia.URL = proto.String(resp.URL)
+		typedMedia.DirectPath = proto.String(resp.DirectPath)
+		typedMedia.MediaKey = resp.MediaKey
+		typedMedia.MediaKeyTimestamp = mediaKeyTimestamp
+		typedMedia.FileEncSHA256 = resp.FileEncSHA256
+		typedMedia.FileSHA256 = resp.FileSHA256
+		typedMedia.FileLength = proto.Uint64(resp.FileLength)
+		typedMedia.ThumbnailDirectPath, typedMedia.ThumbnailSHA256, typedMedia.ThumbnailEncSHA256 = nil, nil, nil
+	case *waE2E.VideoMessage:
+		typedMedia.URL = proto.String(resp.URL)
+		typedMedia.DirectPath = proto.String(resp.DirectPath)
+		typedMedia.MediaKey = resp.MediaKey
+		typedMedia.MediaKeyTimestamp = mediaKeyTimestamp
+		typedMedia.FileEncSHA256 = resp.FileEncSHA256
+		typedMedia.FileSHA256 = resp.FileSHA256
+		typedMedia.FileLength = proto.Uint64(resp.FileLength)
+		typedMedia.StreamingSidecar = resp.StreamingSidecar
+		typedMedia.ThumbnailDirectPath, typedMedia.ThumbnailSHA256, typedMedia.ThumbnailEncSHA256 = nil, nil, nil
+	case *waE2E.AudioMessage:
+		typedMedia.URL = proto.String(resp.URL)
+		typedMedia.DirectPath = proto.String(resp.DirectPath)
+		typedMedia.MediaKey = resp.MediaKey
+		typedMedia.MediaKeyTimestamp = mediaKeyTimestamp
+		typedMedia.FileEncSHA256 = resp.FileEncSHA256
+		typedMedia.FileSHA256 = resp.FileSHA256
+		typedMedia.FileLength = proto.Uint64(resp.FileLength)
+		typedMedia.StreamingSidecar = resp.StreamingSidecar
+	case *waE2E.DocumentMessage:
+		typedMedia.URL = proto.String(resp.URL)
+		typedMedia.DirectPath = proto.String(resp.DirectPath)
+		typedMedia.MediaKey = resp.MediaKey
+		typedMedia.MediaKeyTimestamp = mediaKeyTimestamp
+		typedMedia.FileEncSHA256 = resp.FileEncSHA256
+		typedMedia.FileSHA256 = resp.FileSHA256
+		typedMedia.FileLength = proto.Uint64(resp.FileLength)
+		typedMedia.ThumbnailDirectPath, typedMedia.ThumbnailSHA256, typedMedia.ThumbnailEncSHA256 = nil, nil, nil
+	case *waE2E.StickerMessage:
+		typedMedia.URL = proto.String(resp.URL)
+		typedMedia.DirectPath = proto.String(resp.DirectPath)
+		typedMedia.MediaKey = resp.MediaKey
+		typedMedia.MediaKeyTimestamp = mediaKeyTimestamp
+		typedMedia.FileEncSHA256 = resp.FileEncSHA256
+		typedMedia.FileSHA256 = resp.FileSHA256
+		typedMedia.FileLength = proto.Uint64(resp.FileLength)
+	}
+}
+
+// AttachLinkThumbnail uploads a high-quality link preview thumbnail and adds it to the given message.
+//
+// The image is resized to fit in [LinkThumbnailSize] and a small inline thumbnail is also added to the JPEGThumbnail field.
+func (cli *Client) AttachLinkThumbnail(ctx context.Context, msg *waE2E.ExtendedTextMessage, imageData []byte) error {
+	if cli == nil {
+		return ErrClientIsNil
+	}
+	return AttachLinkThumbnail(ctx, cli, msg, imageData)
+}
+
+// AttachLinkThumbnail uploads a link preview thumbnail with the given uploader and adds it to the given message.
+//
+// See [Client.AttachLinkThumbnail] for more info.
+func AttachLinkThumbnail(ctx context.Context, uploader MediaUploader, msg *waE2E.ExtendedTextMessage, imageData []byte) error {
+	img, _, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return fmt.Errorf("failed to decode image: %w", err)
+	}
+	thumbnail, width, height, err := encodeJPEGThumbnail(img, LinkThumbnailSize)
+	if err != nil {
+		return fmt.Errorf("failed to generate thumbnail: %w", err)
+	}
+	inlineThumbnail, _, _, err := encodeJPEGThumbnail(img, InlineThumbnailSize)
+	if err != nil {
+		return fmt.Errorf("failed to generate inline thumbnail: %w", err)
+	}
+	resp, err := uploader.Upload(ctx, thumbnail, MediaLinkThumbnail)
+	if err != nil {
+		return fmt.Errorf("failed to upload thumbnail: %w", err)
+	}
+	msg.JPEGThumbnail = inlineThumbnail
+	msg.ThumbnailDirectPath = proto.String(resp.DirectPath)
+	msg.ThumbnailSHA256 = resp.FileSHA256
+	msg.ThumbnailEncSHA256 = resp.FileEncSHA256
+	msg.MediaKey = resp.MediaKey
+	msg.MediaKeyTimestamp = proto.Int64(time.Now().Unix())
+	msg.ThumbnailWidth = proto.Uint32(uint32(width))
+	msg.ThumbnailHeight = proto.Uint32(uint32(height))
+	return nil
+}
+
+// DetectMimeType guesses the mime type of the given file.
+//
+// The file contents are checked first. If the contents are not recognized, the extension of the file name is used.
+func DetectMimeType(data []byte, fileName string) string {
+	// http.DetectContentType returns application/ogg for all Ogg files, but voice messages must be audio/ogg with the codec
+	if isOggOpus(data) {
+		return "audio/ogg; codecs=opus"
+	}
+	mimeType := http.DetectContentType(data)
+	if mimeType == "application/octet-stream" || strings.HasPrefix(mimeType, "text/plain") {
+		if dot := strings.LastIndexByte(fileName, '.'); dot >= 0 {
+			if byExt := mime.TypeByExtension(fileName[dot:]); byExt != "" {
+				mimeType = byExt
+			}
+		}
+	}
+	// WhatsApp doesn't like parameters in the mime type, except for a few special cases like voice messages
+	if semicolon := strings.IndexByte(mimeType, ';'); semicolon > 0 && !strings.HasPrefix(mimeType, "audio/ogg") {
+		mimeType = mimeType[:semicolon]
+	}
+	return mimeType
+}
+
+// isOggOpus checks if the data is an Ogg container whose first packet is an Opus identification header.
+func isOggOpus(data []byte) bool {
+	const oggPageHeaderSize = 27
+	if len(data) < oggPageHeaderSize || !bytes.HasPrefix(data, []byte("OggS")) {
+		return false
+	}
+	packetStart := oggPageHeaderSize + int(data[oggPageHeaderSize-1])
+	return len(data) >= packetStart+8 && bytes.Equal(data[packetStart:packetStart+8], []byte("OpusHead"))
+}
+
+// GenerateJPEGThumbnail decodes the given image and creates a JPEG thumbnail that fits in a maxSize*maxSize box.
+// The returned width and height are the dimensions of the thumbnail.
+func GenerateJPEGThumbnail(imageData []byte, maxSize int) (thumbnail []byte, width, height int, err error) {
+	img, _, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("%w: %w", ErrUnsupportedImageFormat, err)
+	}
+	return encodeJPEGThumbnail(img, maxSize)
+}
+
+func encodeJPEGThumbnail(img image.Image, maxSize int) ([]byte, int, int, error) {
+	scaled := scaleImage(img, maxSize)
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: thumbnailJPEGQuality})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), scaled.Bounds().Dx(), scaled.Bounds().Dy(), nil
+}
+
+// scaleImage downscales the image to fit in a maxSize*maxSize box using a box filter.
+// Transparent areas are filled with white, as JPEG doesn't support transparency.
+func scaleImage(img image.Image, maxSize int) *image.RGBA {
+	src := img.Bounds()
+	width, height := src.Dx(), src.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = max(1, height*maxSize/width)
+			width = maxSize
+		} else {
+			width = max(1, width*maxSize/height)
+			height = maxSize
+		}
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		srcY0 := src.Min.Y + y*src.Dy()/height
+		srcY1 := max(srcY0+1, src.Min.Y+(y+1)*src.Dy()/height)
+		for x := 0; x < width; x++ {
+			srcX0 := src.Min.X + x*src.Dx()/width
+			srcX1 := max(srcX0+1, src.Min.X+(x+1)*src.Dx()/width)
+			var r, g, b, a, n uint64
+			for sy := srcY0; sy < srcY1; sy++ {
+				for sx := srcX0; sx < srcX1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+			// Colors are premultiplied, so blending onto white is just adding the remaining alpha as white.
+			white := 0xffff*n - a
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r + white) / n >> 8),
+				G: uint8((g + white) / n >> 8),
+				B: uint8((b + white) / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
+
+func nonEmptyString(val string) *string {
+	if val == "" {
+		return nil
+	}
+	return proto.String(val)
+}
+
+func nonFalseBool(val bool) *bool {
+	if !val {
+		return nil
+	}
+	return proto.Bool(true)
+}
+
+func nonZeroUint32(val uint32) *uint32 {
+	if val == 0 {
+		return nil
+	}
+	return proto.Uint32(val)
+}
+
+func nonZeroSeconds(dur time.Duration) *uint32 {
+	if dur <= 0 {
+		return nil
+	}
+	return proto.Uint32(uint32(dur.Round(time.Second) / time.Second))
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+type fakeMediaUploader struct {
+	uploads []MediaType
+}
+
+func (fmu *fakeMediaUploader) Upload(ctx context.Context, plaintext []byte, appInfo MediaType) (UploadResponse, error) {
+	fmu.uploads = append(fmu.uploads, appInfo)
+	return UploadResponse{
+		URL:        "https://mmg.whatsapp.net/fake",
+		DirectPath: "/fake",
+		MediaKey:   []byte("media key"),
+		FileLength: uint64(len(plaintext)),
+	}, nil
+}
+
+func makeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// makeTestOgg builds the first page of an Ogg stream containing the given identification header.
+func makeTestOgg(header string) []byte {
+	page := []byte("OggS\x00\x02")
+	page = append(page, make([]byte, 20)...)
+	page = append(page, 1, byte(len(header)))
+	return append(page, header+"\x01\x01\x38\x01"...)
+}
+
+func TestDetectMimeType(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		fileName string
+		expected string
+	}{
+		{"PNG", makeTestPNG(t, 4, 4), "", "image/png"},
+		{"OggOpus", makeTestOgg("OpusHead"), "", "audio/ogg; codecs=opus"},
+		{"OggVorbis", makeTestOgg("\x01vorbis\x00"), "", "application/ogg"},
+		{"UnknownWithExtension", []byte{0x00, 0x01, 0x02, 0x03}, "file.pdf", "application/pdf"},
+		{"PlainTextWithParameters", []byte("hello"), "", "text/plain"},
+		{"Unknown", []byte{0x00, 0x01, 0x02, 0x03}, "", "application/octet-stream"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if mimeType := DetectMimeType(test.data, test.fileName); mimeType != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, mimeType)
+			}
+		})
+	}
+}
+
+func TestBuildMediaMessage_Image(t *testing.T) {
+	uploader := &fakeMediaUploader{}
+	msg, err := BuildMediaMessage(context.Background(), uploader, MediaMessageParams{
+		Data:    makeTestPNG(t, 300, 200),
+		Caption: "caption",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := msg.GetImageMessage()
+	if img == nil {
+		t.Fatalf("expected image message, got %v", msg)
+	}
+	if img.GetWidth() != 300 || img.GetHeight() != 200 {
+		t.Errorf("expected 300x200, got %dx%d", img.GetWidth(), img.GetHeight())
+	}
+	if img.GetMimetype() != "image/png" || img.GetCaption() != "caption" || img.GetDirectPath() != "/fake" {
+		t.Errorf("unexpected fields in %v", img)
+	}
+	thumb, err := jpeg.DecodeConfig(bytes.NewReader(img.GetJPEGThumbnail()))
+	if err != nil {
+		t.Fatalf("failed to decode thumbnail: %v", err)
+	}
+	if thumb.Width != InlineThumbnailSize || thumb.Height != InlineThumbnailSize*200/300 {
+		t.Errorf("unexpected thumbnail size %dx%d", thumb.Width, thumb.Height)
+	}
+	if len(uploader.uploads) != 1 || uploader.uploads[0] != MediaImage {
+		t.Errorf("unexpected uploads %v", uploader.uploads)
+	}
+}
+
+func TestBuildMediaMessage_VoiceMessage(t *testing.T) {
+	uploader := &fakeMediaUploader{}
+	msg, err := BuildMediaMessage(context.Background(), uploader, MediaMessageParams{
+		Data: makeTestOgg("OpusHead"),
+		PTT:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	audio := msg.GetAudioMessage()
+	if audio == nil {
+		t.Fatalf("expected audio message, got %v", msg)
+	}
+	if !audio.GetPTT() || audio.GetMimetype() != "audio/ogg; codecs=opus" {
+		t.Errorf("unexpected fields in %v", audio)
+	}
+	if len(uploader.uploads) != 1 || uploader.uploads[0] != MediaAudio {
+		t.Errorf("unexpected uploads %v", uploader.uploads)
+	}
+}
+
+func TestBuildMediaMessage_Document(t *testing.T) {
+	uploader := &fakeMediaUploader{}
+	msg, err := BuildMediaMessage(context.Background(), uploader, MediaMessageParams{
+		Data:       makeTestPNG(t, 100, 50),
+		FileName:   "image.png",
+		AsDocument: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := msg.GetDocumentMessage()
+	if doc == nil {
+		t.Fatalf("expected document message, got %v", msg)
+	}
+	if doc.GetFileName() != "image.png" || doc.GetMimetype() != "image/png" {
+		t.Errorf("unexpected fields in %v", doc)
+	}
+	if doc.GetThumbnailWidth() != InlineThumbnailSize || doc.GetThumbnailHeight() != InlineThumbnailSize/2 || len(doc.GetJPEGThumbnail()) == 0 {
+		t.Errorf("unexpected thumbnail in %v", doc)
+	}
+	if len(uploader.uploads) != 1 || uploader.uploads[0] != MediaDocument {
+		t.Errorf("unexpected uploads %v", uploader.uploads)
+	}
+}
+
+func TestBuildMediaMessage_NoData(t *testing.T) {
+	_, err := BuildMediaMessage(context.Background(), &fakeMediaUploader{}, MediaMessageParams{})
+	if !errors.Is(err, ErrNoMediaData) {
+		t.Errorf("expected ErrNoMediaData, got %v", err)
+	}
+}
@@ -1145,7 +1145,28 @@ func (cli *Client) handleDecryptedMessage(ctx context.Context, info *types.Messa
 		return false
 	}
 	evt := &events.Message{Info: *info, RawMessage: msg, RetryCount: retryCount}
-	return cli.dispatchEvent(evt.UnwrapRaw())
+	if !info.IsFromMe {
+		cli.clearChatTyping(info.Chat, info.Sender)
+	}
+	evt.UnwrapRaw()
+	if cli.EmitAlbumEvents {
+		cli.handleAlbumPart(evt)
+	}
+	var historyBundleEvt *events.Message
+	if bundle := evt.Message.GetMessageHistoryBundle(); bundle != nil && !info.IsFromMe && cli.AutomaticGroupHistoryDownload && cli.isGroupHistoryReceiver(bundle) {
+		// Copy the bundle before dispatching, as event handlers may keep and modify the event
+		historyBundleEvt = &events.Message{
+			Info: evt.Info,
+			Message: &waE2E.Message{
+				MessageHistoryBundle: proto.Clone(bundle).(*waE2E.MessageHistoryBundle),
+			},
+		}
+	}
+	handlerFailed = cli.dispatchEvent(evt)
+	if historyBundleEvt != nil {
+		go cli.handleGroupHistoryBundle(cli.BackgroundEventCtx, historyBundleEvt)
+	}
+	return
 }
 
 // SendProtocolMessageReceipt sends a receipt for a protocol message back to the phone.
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/beeper/argo-go/codec"
+	"github.com/beeper/argo-go/pkg/buf"
+	"github.com/beeper/argo-go/wire"
+	"github.com/elliotchance/orderedmap/v3"
+
+	"go.mau.fi/whatsmeow/argo"
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/proto/waWa6"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+)
+
+var (
+	ErrUnknownMexOperation = errors.New("unknown mex operation")
+	ErrNoArgoWireType      = errors.New("no argo wire type found for mex operation")
+)
+
+// mexWebQueryIDs contains the query IDs that WhatsApp Web uses for operations.
+// The IDs in argo/name-to-queryids.json are the ones used by the desktop and mobile apps.
+var mexWebQueryIDs = map[string]string{
+	"NewsletterMetadata":       queryFetchNewsletter,
+	"NewsletterRecommended":    queryRecommendedNewsletters,
+	"NewsletterDirectoryList":  queryNewslettersDirectory,
+	"NewsletterSubscribed":     querySubscribedNewsletters,
+	"NewsletterSubscribers":    queryNewsletterSubscribers,
+	"NewsletterMute":           mutationMuteNewsletter,
+	"NewsletterUnmute":         mutationUnmuteNewsletter,
+	"NewsletterMetadataUpdate": mutationUpdateNewsletter,
+	"NewsletterCreate":         mutationCreateNewsletter,
+	"NewsletterJoin":           mutationFollowNewsletter,
+	"NewsletterLeave":          mutationUnfollowNewsletter,
+}
+
+func (cli *Client) usesWebMexQueryIDs() bool {
+	return payloadUsesWebMexQueryIDs(cli.Store.GetClientPayload())
+}
+
+// payloadUsesWebMexQueryIDs returns true if the web query IDs should be used with the given client payload.
+// Clients that send web info use the web IDs regardless of the user agent platform.
+func payloadUsesWebMexQueryIDs(payload *waWa6.ClientPayload) bool {
+	return payload.GetWebInfo() != nil
+}
+
+func (cli *Client) getMexQueryID(operation string) (string, error) {
+	if cli.usesWebMexQueryIDs() {
+		if queryID, ok := mexWebQueryIDs[operation]; ok {
+			return queryID, nil
+		}
+	}
+	queryIDs, err := argo.GetMessageNameToQueryID()
+	if err != nil {
+		return "", fmt.Errorf("failed to load mex query IDs: %w", err)
+	}
+	queryID, ok := queryIDs[operation]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownMexOperation, operation)
+	}
+	return queryID, nil
+}
+
+// SendMexQuery sends a MEX (GraphQL) query or mutation and returns the raw JSON data from the response.
+//
+// The operation is referred to by name (e.g. "NewsletterMetadata"), see argo/name-to-queryids.json for the list
+// of known operations. The query ID for the current client platform is chosen automatically.
+// The variables are sent as JSON and must match what the operation expects.
+//
+// If the server returns GraphQL errors, the error will wrap a [types.GraphQLErrors],
+// and any partial data will be returned along with it.
+//
+// If the server responds in argo and there's no wire type for the operation, the error will wrap [ErrNoArgoWireType].
+// The request itself was still processed, so for mutations, the change may have been applied.
+func (cli *Client) SendMexQuery(ctx context.Context, operation string, variables any) (json.RawMessage, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	queryID, err := cli.getMexQueryID(operation)
+	if err != nil {
+		return nil, err
+	}
+	return cli.sendMexIQWithOperation(ctx, queryID, operation, variables)
+}
+
+// MexQuery sends a MEX (GraphQL) query like [Client.SendMexQuery] and unmarshals the response data into a T.
+//
+// If the server returns GraphQL errors along with partial data, both the parsed data and the error are returned.
+// Otherwise, the returned pointer is only nil if there's an error.
+//
+//	type respNewsletterSubscribed struct {
+//		Newsletters []*types.NewsletterMetadata `json:"xwa2_newsletter_subscribed"`
+//	}
+//	resp, err := whatsmeow.MexQuery[respNewsletterSubscribed](ctx, cli, "NewsletterSubscribed", map[string]any{})
+func MexQuery[T any](ctx context.Context, cli *Client, operation string, variables any) (*T, error) {
+	data, err := cli.SendMexQuery(ctx, operation, variables)
+	var out T
+	if len(data) == 0 || string(data) == "null" {
+		if err != nil {
+			return nil, err
+		}
+		return &out, nil
+	}
+	jsonErr := json.Unmarshal(data, &out)
+	if jsonErr != nil {
+		if err == nil {
+			err = fmt.Errorf("failed to unmarshal mex response data: %w", jsonErr)
+		}
+		return nil, err
+	}
+	return &out, err
+}
+
+func (cli *Client) sendMexIQ(ctx context.Context, queryID string, variables any) (json.RawMessage, error) {
+	if store.BaseClientPayload.GetUserAgent().GetPlatform() == waWa6.ClientPayload_UserAgent_MACOS {
+		return nil, fmt.Errorf("argo decoding is currently broken")
+	}
+	return cli.sendMexIQWithOperation(ctx, convertQueryID(cli, queryID), "", variables)
+}
+
+func (cli *Client) sendMexIQWithOperation(ctx context.Context, queryID, operation string, variables any) (json.RawMessage, error) {
+	payload, err := json.Marshal(map[string]any{
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "w:mex",
+		Type:      iqGet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "query",
+			Attrs: waBinary.Attrs{
+				"query_id": queryID,
+			},
+			Content: payload,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, ok := resp.GetOptionalChildByTag("result")
+	if !ok {
+		return nil, &ElementMissingError{Tag: "result", In: "mex response"}
+	}
+	resultContent, ok := result.Content.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected content type %T in mex response", result.Content)
+	}
+	if result.AttrGetter().OptionalString("format") == "argo" {
+		if operation == "" {
+			queryIDMap, err := argo.GetQueryIDToMessageName()
+			if err != nil {
+				return nil, err
+			}
+			operation = queryIDMap[queryID]
+		}
+		resultContent, err = decodeArgoMexResponse(operation, resultContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode argo mex response: %w", err)
+		}
+	}
+	var gqlResp types.GraphQLResponse
+	err = json.Unmarshal(resultContent, &gqlResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal graphql response: %w", err)
+	} else if len(gqlResp.Errors) > 0 {
+		return gqlResp.Data, fmt.Errorf("graphql error: %w", gqlResp.Errors)
+	}
+	return gqlResp.Data, nil
+}
+
+// decodeArgoMexResponse decodes an Argo-encoded GraphQL response of the given operation into JSON.
+func decodeArgoMexResponse(operation string, data []byte) ([]byte, error) {
+	store, err := argo.GetStore()
+	if err != nil {
+		return nil, err
+	}
+	wt, ok := store[operation]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrNoArgoWireType, operation)
+	}
+	decoder, err := codec.NewArgoDecoder(buf.NewBufReadonly(data))
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := decoder.ArgoToMap(wt)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(argoValueToJSON(decoded, wt))
+}
+
+// argoValueToJSON converts the ordered maps returned by the Argo decoder into plain maps,
+// as the ordered map type doesn't implement json.Marshaler.
+//
+// The wire type is used to convert JID scalars, which are sent as bytes, into strings,
+// so that they can be unmarshaled into types.JID like in JSON responses.
+func argoValueToJSON(val any, wt wire.Type) any {
+	switch typedType := wt.(type) {
+	case wire.NullableType:
+		return argoValueToJSON(val, typedType.Of)
+	case wire.BlockType:
+		if bytesVal, ok := val.([]byte); ok && strings.HasSuffix(string(typedType.Key), "JID") {
+			return string(bytesVal)
+		}
+		return argoValueToJSON(val, typedType.Of)
+	}
+	switch typedVal := val.(type) {
+	case *orderedmap.OrderedMap[string, any]:
+		fieldTypes := make(map[string]wire.Type)
+		if record, ok := wt.(wire.RecordType); ok {
+			for _, field := range record.Fields {
+				fieldTypes[field.Name] = field.Of
+			}
+		}
+		out := make(map[string]any, typedVal.Len())
+		for key, item := range typedVal.AllFromFront() {
+			out[key] = argoValueToJSON(item, fieldTypes[key])
+		}
+		return out
+	case []any:
+		var itemType wire.Type
+		if array, ok := wt.(wire.ArrayType); ok {
+			itemType = array.Of
+		}
+		out := make([]any, len(typedVal))
+		for i, item := range typedVal {
+			out[i] = argoValueToJSON(item, itemType)
+		}
+		return out
+	default:
+		return val
+	}
+}
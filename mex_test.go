@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/beeper/argo-go/wire"
+	"github.com/elliotchance/orderedmap/v3"
+
+	"go.mau.fi/whatsmeow/proto/waWa6"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// testdata/newsletter-metadata.argo is a NewsletterMetadata response recorded from the WhatsApp servers
+// (from the github.com/beeper/argo-go test suite, which uses the same wire type store).
+func TestDecodeArgoMexResponse_NewsletterMetadata(t *testing.T) {
+	data, err := os.ReadFile("testdata/newsletter-metadata.argo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodeArgoMexResponse("NewsletterMetadata", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gqlResp types.GraphQLResponse
+	if err = json.Unmarshal(decoded, &gqlResp); err != nil {
+		t.Fatal(err)
+	}
+	var resp respGetNewsletterInfo
+	if err = json.Unmarshal(gqlResp.Data, &resp); err != nil {
+		t.Fatalf("failed to unmarshal decoded response: %v\n%s", err, decoded)
+	}
+	meta := resp.Newsletter
+	if meta == nil {
+		t.Fatalf("newsletter missing in decoded response:\n%s", decoded)
+	}
+	if expected := types.NewJID("120363166407666729", types.NewsletterServer); meta.ID != expected {
+		t.Errorf("expected ID %s, got %s", expected, meta.ID)
+	}
+	if meta.ThreadMeta.Name.Text != "Page Six" {
+		t.Errorf("unexpected name %q", meta.ThreadMeta.Name.Text)
+	}
+	if meta.ThreadMeta.SubscriberCount != 58971 {
+		t.Errorf("unexpected subscriber count %d", meta.ThreadMeta.SubscriberCount)
+	}
+	if meta.ThreadMeta.InviteCode != "0029Va6QtCHAO7RJXEHdHi3z" {
+		t.Errorf("unexpected invite code %q", meta.ThreadMeta.InviteCode)
+	}
+	if meta.ThreadMeta.CreationTime.Unix() != 1692989664 {
+		t.Errorf("unexpected creation time %s", meta.ThreadMeta.CreationTime)
+	}
+	if meta.ThreadMeta.Picture == nil || meta.ThreadMeta.Picture.DirectPath == "" {
+		t.Errorf("picture missing in decoded response")
+	}
+}
+
+func TestDecodeArgoMexResponse_UnknownOperation(t *testing.T) {
+	_, err := decodeArgoMexResponse("ThisOperationDoesNotExist", nil)
+	if !errors.Is(err, ErrNoArgoWireType) {
+		t.Errorf("expected ErrNoArgoWireType, got %v", err)
+	}
+}
+
+func TestArgoValueToJSON_JID(t *testing.T) {
+	wt := wire.RecordType{Fields: []wire.Field{
+		{Name: "id", Of: wire.NullableType{Of: wire.BlockType{Of: wire.String, Key: "JID"}}},
+		{Name: "name", Of: wire.BlockType{Of: wire.Bytes, Key: "Bytes"}},
+		{Name: "members", Of: wire.ArrayType{Of: wire.BlockType{Of: wire.String, Key: "UserJID"}}},
+	}}
+	val := orderedmap.NewOrderedMap[string, any]()
+	val.Set("id", []byte("123@g.us"))
+	val.Set("name", []byte("raw"))
+	val.Set("members", []any{[]byte("1@s.whatsapp.net"), []byte("2@lid")})
+	out, err := json.Marshal(argoValueToJSON(val, wt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed struct {
+		ID      types.JID   `json:"id"`
+		Name    []byte      `json:"name"`
+		Members []types.JID `json:"members"`
+	}
+	if err = json.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", out, err)
+	}
+	if parsed.ID != types.NewJID("123", types.GroupServer) || string(parsed.Name) != "raw" ||
+		len(parsed.Members) != 2 || parsed.Members[1] != types.NewJID("2", types.HiddenUserServer) {
+		t.Errorf("unexpected output %s", out)
+	}
+}
+
+func TestPayloadUsesWebMexQueryIDs(t *testing.T) {
+	macOS := &waWa6.ClientPayload_UserAgent{Platform: waWa6.ClientPayload_UserAgent_MACOS.Enum()}
+	web := &waWa6.ClientPayload_UserAgent{Platform: waWa6.ClientPayload_UserAgent_WEB.Enum()}
+	tests := []struct {
+		name     string
+		payload  *waWa6.ClientPayload
+		expected bool
+	}{
+		{"web", &waWa6.ClientPayload{UserAgent: web, WebInfo: &waWa6.ClientPayload_WebInfo{}}, true},
+		{"macOS with web info", &waWa6.ClientPayload{UserAgent: macOS, WebInfo: &waWa6.ClientPayload_WebInfo{}}, true},
+		{"macOS", &waWa6.ClientPayload{UserAgent: macOS}, false},
+		{"no web info", &waWa6.ClientPayload{UserAgent: web}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := payloadUsesWebMexQueryIDs(test.payload); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/util/jsontime"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+type respNewsletterAdminInvite struct {
+	Invite struct {
+		ID             types.JID           `json:"id"`
+		ExpirationTime jsontime.UnixString `json:"invite_expiration_time"`
+	} `json:"xwa2_newsletter_admin_invite_create"`
+}
+
+// NewsletterInviteAdmin invites a user to become an admin of a newsletter you own.
+//
+// The user will receive an admin invite message, which they can accept with [Client.NewsletterAcceptAdminInvite].
+// The returned time is when the invite expires.
+func (cli *Client) NewsletterInviteAdmin(ctx context.Context, jid, user types.JID) (time.Time, error) {
+	resp, err := MexQuery[respNewsletterAdminInvite](ctx, cli, "NewsletterAdminInvite", map[string]any{
+		"newsletter_id": jid.String(),
+		"user_id":       user.String(),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return resp.Invite.ExpirationTime.Time, nil
+}
+
+// NewsletterRevokeAdminInvite revokes a pending admin invite sent with [Client.NewsletterInviteAdmin].
+func (cli *Client) NewsletterRevokeAdminInvite(ctx context.Context, jid, user types.JID) error {
+	_, err := cli.SendMexQuery(ctx, "NewsletterAdminInviteRevoke", map[string]any{
+		"newsletter_id": jid.String(),
+		"user_id":       user.String(),
+	})
+	return err
+}
+
+// NewsletterAcceptAdminInvite accepts an invite to become an admin of a newsletter.
+func (cli *Client) NewsletterAcceptAdminInvite(ctx context.Context, jid types.JID) error {
+	_, err := cli.SendMexQuery(ctx, "NewsletterAcceptAdminInvite", map[string]any{
+		"newsletter_id": jid.String(),
+	})
+	return err
+}
+
+// NewsletterDemoteAdmin demotes an admin of a newsletter you own back to a normal follower.
+//
+// Admins can also demote themselves by passing their own JID.
+func (cli *Client) NewsletterDemoteAdmin(ctx context.Context, jid, user types.JID) error {
+	_, err := cli.SendMexQuery(ctx, "NewsletterAdminDemote", map[string]any{
+		"newsletter_id": jid.String(),
+		"user_id":       user.String(),
+	})
+	return err
+}
+
+// NewsletterChangeOwner transfers the ownership of a newsletter to another admin.
+// You will remain an admin of the newsletter after the transfer.
+func (cli *Client) NewsletterChangeOwner(ctx context.Context, jid, newOwner types.JID) error {
+	_, err := cli.SendMexQuery(ctx, "NewsletterChangeOwner", map[string]any{
+		"newsletter_id": jid.String(),
+		"user_id":       newOwner.String(),
+	})
+	return err
+}
+
+// DeleteNewsletter deletes a newsletter you own. This can't be undone.
+func (cli *Client) DeleteNewsletter(ctx context.Context, jid types.JID) error {
+	_, err := cli.SendMexQuery(ctx, "NewsletterDelete", map[string]any{
+		"newsletter_id": jid.String(),
+	})
+	return err
+}
+
+// NewsletterBlockUser blocks or unblocks a user from following and reacting to a newsletter you administer.
+func (cli *Client) NewsletterBlockUser(ctx context.Context, jid, user types.JID, block bool) error {
+	_, err := cli.SendMexQuery(ctx, "NewsletterBlockUser", map[string]any{
+		"newsletter_id": jid.String(),
+		"user_id":       user.String(),
+		"block":         block,
+	})
+	return err
+}
+
+// UpdateNewsletterParams contains the fields to change in [Client.UpdateNewsletter].
+// Fields that are left as nil are not changed.
+type UpdateNewsletterParams struct {
+	Name        *string
+	Description *string
+	// The new picture as a JPEG. Use an empty non-nil slice to remove the picture.
+	Picture       []byte
+	ReactionsMode *types.NewsletterReactionsMode
+}
+
+type respUpdateNewsletter struct {
+	Newsletter *types.NewsletterMetadata `json:"xwa2_newsletter_update"`
+}
+
+// UpdateNewsletter changes the name, description, picture or reaction settings of a newsletter you administer.
+func (cli *Client) UpdateNewsletter(ctx context.Context, jid types.JID, params UpdateNewsletterParams) (*types.NewsletterMetadata, error) {
+	updates := map[string]any{}
+	if params.Name != nil {
+		updates["name"] = *params.Name
+	}
+	if params.Description != nil {
+		updates["description"] = *params.Description
+	}
+	if params.Picture != nil {
+		updates["picture"] = params.Picture
+	}
+	if params.ReactionsMode != nil {
+		updates["settings"] = map[string]any{
+			"reaction_codes": map[string]any{
+				"value": strings.ToUpper(string(*params.ReactionsMode)),
+			},
+		}
+	}
+	resp, err := MexQuery[respUpdateNewsletter](ctx, cli, "NewsletterMetadataUpdate", map[string]any{
+		"newsletter_id": jid.String(),
+		"updates":       updates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Newsletter, nil
+}
+
+// GetNewsletterSubscribersParams contains the pagination parameters for [Client.GetNewsletterSubscribers].
+type GetNewsletterSubscribersParams struct {
+	// The maximum number of subscribers to return.
+	Count int
+	// The cursor to continue from, i.e. PageInfo.EndCursor from the previous page.
+	After string
+}
+
+type respGetNewsletterSubscribers struct {
+	Newsletter struct {
+		Subscribers *types.NewsletterSubscriberList `json:"subscribers"`
+	} `json:"xwa2_newsletter_subscribers"`
+}
+
+// GetNewsletterSubscribers gets one page of the subscribers of a newsletter you administer.
+//
+//	var all []types.NewsletterSubscriber
+//	params := &whatsmeow.GetNewsletterSubscribersParams{Count: 100}
+//	for {
+//		page, err := cli.GetNewsletterSubscribers(ctx, jid, params)
+//		// handle error
+//		all = append(all, page.Subscribers...)
+//		if !page.PageInfo.HasNextPage {
+//			break
+//		}
+//		params.After = page.PageInfo.EndCursor
+//	}
+func (cli *Client) GetNewsletterSubscribers(ctx context.Context, jid types.JID, params *GetNewsletterSubscribersParams) (*types.NewsletterSubscriberList, error) {
+	input := map[string]any{
+		"newsletter_id": jid.String(),
+	}
+	if params != nil {
+		if params.Count != 0 {
+			input["count"] = params.Count
+		}
+		if params.After != "" {
+			input["after"] = params.After
+		}
+	}
+	resp, err := MexQuery[respGetNewsletterSubscribers](ctx, cli, "NewsletterSubscribers", map[string]any{
+		"input": input,
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Newsletter.Subscribers == nil {
+		return &types.NewsletterSubscriberList{}, nil
+	}
+	return resp.Newsletter.Subscribers, nil
+}
+
+type respGetNewsletterInsights struct {
+	Insights *types.NewsletterInsights `json:"xwa2_newsletter_admin_insights"`
+}
+
+// GetNewsletterInsights gets engagement statistics of a newsletter you administer.
+//
+// If no metrics are specified, the default set of metrics chosen by the server is returned.
+func (cli *Client) GetNewsletterInsights(ctx context.Context, jid types.JID, metrics ...types.NewsletterInsightMetric) (*types.NewsletterInsights, error) {
+	input := map[string]any{
+		"newsletter_id": jid.String(),
+	}
+	if len(metrics) > 0 {
+		input["metrics"] = metrics
+	}
+	resp, err := MexQuery[respGetNewsletterInsights](ctx, cli, "NewsletterInsights", map[string]any{
+		"input": input,
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Insights == nil {
+		return &types.NewsletterInsights{}, nil
+	}
+	return resp.Insights, nil
+}
+
+// GetNewsletterReactionListParams contains the pagination parameters for [Client.GetNewsletterReactionSenders]
+// and [Client.GetNewsletterPollVoters].
+type GetNewsletterReactionListParams struct {
+	// The maximum number of users to return for each reaction or poll option.
+	Count int
+	// The cursor to continue from, i.e. PageInfo.EndCursor from the previous page.
+	After string
+}
+
+func (params *GetNewsletterReactionListParams) toInput(jid types.JID, serverID types.MessageServerID) map[string]any {
+	input := map[string]any{
+		"newsletter_id": jid.String(),
+		"server_id":     strconv.Itoa(serverID),
+	}
+	if params != nil {
+		if params.Count != 0 {
+			input["count"] = params.Count
+		}
+		if params.After != "" {
+			input["after"] = params.After
+		}
+	}
+	return input
+}
+
+// makeReactionListPageInfo builds the page info for reaction and poll voter lists.
+// The server doesn't return page info for them, so the cursor is the ID of the last user on the page,
+// and there's assumed to be a next page if any group was filled up to the requested count.
+func makeReactionListPageInfo(params *GetNewsletterReactionListParams, groupUsers [][]types.NewsletterUser) types.NewsletterPageInfo {
+	var pageInfo types.NewsletterPageInfo
+	for _, users := range groupUsers {
+		if len(users) == 0 {
+			continue
+		}
+		pageInfo.EndCursor = users[len(users)-1].ID.String()
+		if params != nil && params.Count > 0 && len(users) >= params.Count {
+			pageInfo.HasNextPage = true
+		}
+	}
+	if params != nil && params.After != "" {
+		pageInfo.StartCursor = params.After
+		pageInfo.HasPreviousPage = true
+	}
+	return pageInfo
+}
+
+type respGetNewsletterReactionSenders struct {
+	List struct {
+		Reactions []types.NewsletterReactionSenders `json:"reactions"`
+	} `json:"xwa2_newsletters_reaction_sender_list"`
+}
+
+// GetNewsletterReactionSenders gets one page of the users who reacted to a message in a newsletter you administer,
+// grouped by reaction.
+//
+//	params := &whatsmeow.GetNewsletterReactionListParams{Count: 100}
+//	for {
+//		page, err := cli.GetNewsletterReactionSenders(ctx, jid, serverID, params)
+//		// handle error
+//		// use page.Reactions
+//		if !page.PageInfo.HasNextPage {
+//			break
+//		}
+//		params.After = page.PageInfo.EndCursor
+//	}
+func (cli *Client) GetNewsletterReactionSenders(
+	ctx context.Context, jid types.JID, serverID types.MessageServerID, params *GetNewsletterReactionListParams,
+) (*types.NewsletterReactionSenderList, error) {
+	resp, err := MexQuery[respGetNewsletterReactionSenders](ctx, cli, "NewsletterReactionSendersList", map[string]any{
+		"input": params.toInput(jid, serverID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	groupUsers := make([][]types.NewsletterUser, len(resp.List.Reactions))
+	for i, reaction := range resp.List.Reactions {
+		groupUsers[i] = make([]types.NewsletterUser, len(reaction.Senders))
+		for j, sender := range reaction.Senders {
+			groupUsers[i][j] = sender.User
+		}
+	}
+	return &types.NewsletterReactionSenderList{
+		Reactions: resp.List.Reactions,
+		PageInfo:  makeReactionListPageInfo(params, groupUsers),
+	}, nil
+}
+
+type respGetNewsletterPollVoters struct {
+	List struct {
+		Votes []types.NewsletterPollVoters `json:"votes"`
+	} `json:"xwa2_newsletters_poll_voter_list"`
+}
+
+// GetNewsletterPollVoters gets one page of the users who voted in a poll in a newsletter you administer,
+// grouped by the selected option. Pagination works like in [Client.GetNewsletterReactionSenders].
+func (cli *Client) GetNewsletterPollVoters(
+	ctx context.Context, jid types.JID, serverID types.MessageServerID, params *GetNewsletterReactionListParams,
+) (*types.NewsletterPollVoterList, error) {
+	resp, err := MexQuery[respGetNewsletterPollVoters](ctx, cli, "NewsletterPollVoterList", map[string]any{
+		"input": params.toInput(jid, serverID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	groupUsers := make([][]types.NewsletterUser, len(resp.List.Votes))
+	for i, vote := range resp.List.Votes {
+		groupUsers[i] = make([]types.NewsletterUser, len(vote.Voters))
+		for j, voter := range vote.Voters {
+			groupUsers[i][j] = voter.User
+		}
+	}
+	return &types.NewsletterPollVoterList{
+		Votes:    resp.List.Votes,
+		PageInfo: makeReactionListPageInfo(params, groupUsers),
+	}, nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// NewsletterDirectoryFilters contains filters for listing and searching the newsletter directory.
+type NewsletterDirectoryFilters struct {
+	// Only include newsletters popular in the given countries (ISO 3166-1 alpha-2 codes, e.g. "US").
+	CountryCodes []string
+	// Only include newsletters in the given categories.
+	Categories []types.NewsletterDirectoryCategory
+}
+
+func (ndf *NewsletterDirectoryFilters) toMap() map[string]any {
+	filters := map[string]any{}
+	if len(ndf.CountryCodes) > 0 {
+		filters["country_codes"] = ndf.CountryCodes
+	}
+	if len(ndf.Categories) > 0 {
+		filters["categories"] = ndf.Categories
+	}
+	return filters
+}
+
+// GetNewsletterDirectoryParams contains parameters for [Client.GetNewsletterDirectory].
+type GetNewsletterDirectoryParams struct {
+	// The view to list. Defaults to types.NewsletterDirectoryViewRecommended.
+	View    types.NewsletterDirectoryView
+	Filters NewsletterDirectoryFilters
+	// The maximum number of newsletters to return. Defaults to 50.
+	Limit int
+	// The cursor to continue from, i.e. PageInfo.EndCursor from the previous page.
+	Cursor string
+}
+
+type respGetNewsletterDirectory struct {
+	Page *types.NewsletterDirectoryPage `json:"xwa2_newsletters_directory_list"`
+}
+
+// GetNewsletterDirectory gets one page of newsletters from the channel directory.
+func (cli *Client) GetNewsletterDirectory(ctx context.Context, params GetNewsletterDirectoryParams) (*types.NewsletterDirectoryPage, error) {
+	if params.View == "" {
+		params.View = types.NewsletterDirectoryViewRecommended
+	}
+	if params.Limit == 0 {
+		params.Limit = 50
+	}
+	input := map[string]any{
+		"view":    params.View,
+		"limit":   params.Limit,
+		"filters": params.Filters.toMap(),
+	}
+	if params.Cursor != "" {
+		input["start_cursor"] = params.Cursor
+	}
+	resp, err := MexQuery[respGetNewsletterDirectory](ctx, cli, "NewsletterDirectoryList", map[string]any{
+		"input": input,
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Page == nil {
+		return &types.NewsletterDirectoryPage{}, nil
+	}
+	return resp.Page, nil
+}
+
+// SearchNewslettersParams contains parameters for [Client.SearchNewsletters].
+type SearchNewslettersParams struct {
+	Query   string
+	Filters NewsletterDirectoryFilters
+	// The maximum number of newsletters to return. Defaults to 50.
+	Limit int
+	// The cursor to continue from, i.e. PageInfo.EndCursor from the previous page.
+	Cursor string
+}
+
+type respSearchNewsletters struct {
+	Page *types.NewsletterDirectoryPage `json:"xwa2_newsletters_directory_search"`
+}
+
+// SearchNewsletters searches the channel directory for newsletters matching the given text.
+func (cli *Client) SearchNewsletters(ctx context.Context, params SearchNewslettersParams) (*types.NewsletterDirectoryPage, error) {
+	if params.Limit == 0 {
+		params.Limit = 50
+	}
+	input := map[string]any{
+		"search_text": params.Query,
+		"limit":       params.Limit,
+		"filters":     params.Filters.toMap(),
+	}
+	if params.Cursor != "" {
+		input["start_cursor"] = params.Cursor
+	}
+	resp, err := MexQuery[respSearchNewsletters](ctx, cli, "NewsletterDirectorySearch", map[string]any{
+		"input": input,
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Page == nil {
+		return &types.NewsletterDirectoryPage{}, nil
+	}
+	return resp.Page, nil
+}
+
+type respGetRecommendedNewsletters struct {
+	Recommended *types.NewsletterDirectoryPage `json:"xwa2_newsletters_recommended"`
+}
+
+// GetRecommendedNewsletters gets a list of recommended newsletters, optionally limited to the given countries.
+//
+// If limit is zero, it defaults to 20.
+func (cli *Client) GetRecommendedNewsletters(ctx context.Context, countryCodes []string, limit int) ([]*types.NewsletterMetadata, error) {
+	if limit == 0 {
+		limit = 20
+	}
+	input := map[string]any{
+		"limit": limit,
+	}
+	if len(countryCodes) > 0 {
+		input["country_codes"] = countryCodes
+	}
+	resp, err := MexQuery[respGetRecommendedNewsletters](ctx, cli, "NewsletterRecommended", map[string]any{
+		"input": input,
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Recommended == nil {
+		return nil, nil
+	}
+	return resp.Recommended.Newsletters, nil
+}
+
+type respGetSimilarNewsletters struct {
+	Similar *types.NewsletterDirectoryPage `json:"xwa2_newsletters_similar"`
+}
+
+// GetSimilarNewsletters gets a list of newsletters similar to the given one.
+//
+// If limit is zero, it defaults to 20.
+func (cli *Client) GetSimilarNewsletters(ctx context.Context, jid types.JID, limit int) ([]*types.NewsletterMetadata, error) {
+	if limit == 0 {
+		limit = 20
+	}
+	resp, err := MexQuery[respGetSimilarNewsletters](ctx, cli, "NewsletterSimilar", map[string]any{
+		"input": map[string]any{
+			"newsletter_id": jid.String(),
+			"limit":         limit,
+		},
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Similar == nil {
+		return nil, nil
+	}
+	return resp.Similar.Newsletters, nil
+}
+
+type respGetNewsletterDirectoryCategories struct {
+	Preview struct {
+		Categories []*types.NewsletterDirectoryCategoryPreview `json:"result"`
+	} `json:"xwa2_newsletters_directory_category_preview"`
+}
+
+// GetNewsletterDirectoryCategories gets the categories in the channel directory along with a preview of the top newsletters in each.
+//
+// The category IDs can be passed to [Client.GetNewsletterDirectory] in the Filters field to browse a specific category.
+func (cli *Client) GetNewsletterDirectoryCategories(ctx context.Context, countryCodes []string) ([]*types.NewsletterDirectoryCategoryPreview, error) {
+	filters := NewsletterDirectoryFilters{CountryCodes: countryCodes}
+	resp, err := MexQuery[respGetNewsletterDirectoryCategories](ctx, cli, "NewsletterDirectoryCategoryPreview", map[string]any{
+		"input": map[string]any{
+			"filters": filters.toMap(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Preview.Categories, nil
+}
@@ -9,18 +9,9 @@ package whatsmeow
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"log"
-	"maps"
 	"time"
 
-	"github.com/beeper/argo-go/codec"
-	"github.com/beeper/argo-go/pkg/buf"
-
-	"go.mau.fi/whatsmeow/argo"
 	waBinary "go.mau.fi/whatsmeow/binary"
-	"go.mau.fi/whatsmeow/proto/waWa6"
-	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/types"
 )
 
@@ -139,7 +130,7 @@ const (
 )
 
 func convertQueryID(cli *Client, queryID string) string {
-	if payload := cli.Store.GetClientPayload(); payload.GetUserAgent().Platform == waWa6.ClientPayload_UserAgent_MACOS.Enum() || payload.GetWebInfo() == nil {
+	if !cli.usesWebMexQueryIDs() {
 		switch queryID {
 		case queryFetchNewsletter:
 			return queryFetchNewsletterDesktop
@@ -169,79 +160,6 @@ func convertQueryID(cli *Client, queryID string) string {
 	}
 }
 
-func (cli *Client) sendMexIQ(ctx context.Context, queryID string, variables any) (json.RawMessage, error) {
-	if store.BaseClientPayload.GetUserAgent().GetPlatform() == waWa6.ClientPayload_UserAgent_MACOS {
-		return nil, fmt.Errorf("argo decoding is currently broken")
-	}
-	queryID = convertQueryID(cli, queryID)
-	payload, err := json.Marshal(map[string]any{
-		"variables": variables,
-	})
-	if err != nil {
-		return nil, err
-	}
-	resp, err := cli.sendIQ(ctx, infoQuery{
-		Namespace: "w:mex",
-		Type:      iqGet,
-		To:        types.ServerJID,
-		Content: []waBinary.Node{{
-			Tag: "query",
-			Attrs: waBinary.Attrs{
-				"query_id": queryID,
-			},
-			Content: payload,
-		}},
-	})
-	if err != nil {
-		return nil, err
-	}
-	result, ok := resp.GetOptionalChildByTag("result")
-	if !ok {
-		return nil, &ElementMissingError{Tag: "result", In: "mex response"}
-	}
-	resultContent, ok := result.Content.([]byte)
-	if !ok {
-		return nil, fmt.Errorf("unexpected content type %T in mex response", result.Content)
-	}
-	if result.AttrGetter().OptionalString("format") == "argo" {
-		if true {
-			return nil, fmt.Errorf("argo decoding is currently broken")
-		}
-		store, err := argo.GetStore()
-		if err != nil {
-			return nil, err
-		}
-		queryIDMap, err := argo.GetQueryIDToMessageName()
-		if err != nil {
-			return nil, err
-		}
-		wt := store[queryIDMap[queryID]]
-
-		decoder, err := codec.NewArgoDecoder(buf.NewBufReadonly(resultContent))
-		if err != nil {
-			return nil, err
-		}
-		data, err := decoder.ArgoToMap(wt)
-		if err != nil {
-			log.Fatalf("argo to map error: %v", err)
-		}
-		b, err := json.Marshal(maps.Collect(data.AllFromFront()))
-		if err != nil {
-			return nil, err
-		}
-		return b, nil
-	} else {
-		var gqlResp types.GraphQLResponse
-		err = json.Unmarshal(resultContent, &gqlResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal graphql response: %w", err)
-		} else if len(gqlResp.Errors) > 0 {
-			return gqlResp.Data, fmt.Errorf("graphql error: %w", gqlResp.Errors)
-		}
-		return gqlResp.Data, nil
-	}
-}
-
 type respGetNewsletterInfo struct {
 	Newsletter *types.NewsletterMetadata `json:"xwa2_newsletter"`
 }
@@ -388,18 +388,112 @@ func (cli *Client) handleNewsletterNotification(ctx context.Context, node *waBin
 	})
 }
 
-type newsLetterEventWrapper struct {
-	Data newsletterEvent `json:"data"`
+type mexNotificationParser func(data json.RawMessage, mex events.MexNotificationData) (any, error)
+
+func parseMexNotification[T any](getMex func(*T) *events.MexNotificationData) mexNotificationParser {
+	return func(data json.RawMessage, mex events.MexNotificationData) (any, error) {
+		evt := new(T)
+		err := json.Unmarshal(data, evt)
+		if err != nil {
+			return nil, err
+		}
+		*getMex(evt) = mex
+		return evt, nil
+	}
+}
+
+// mexNotificationParsers maps the fields in the data object of mex notifications to functions that parse them into events.
+// Fields that aren't in this map are dispatched as events.MexNotification.
+var mexNotificationParsers = map[string]mexNotificationParser{
+	"xwa2_notify_newsletter_on_join": parseMexNotification(func(evt *events.NewsletterJoin) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_on_leave": parseMexNotification(func(evt *events.NewsletterLeave) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_on_mute_change": parseMexNotification(func(evt *events.NewsletterMuteChange) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_admin_promote": parseMexNotification(func(evt *events.NewsletterAdminPromote) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_admin_demote": parseMexNotification(func(evt *events.NewsletterAdminDemote) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_admin_invite_revoke": parseMexNotification(func(evt *events.NewsletterAdminInviteRevoke) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_on_admin_metadata_update": parseMexNotification(func(evt *events.NewsletterAdminMetadataUpdate) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_owner_on_metadata_update": parseMexNotification(func(evt *events.NewsletterOwnerUpdate) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_on_metadata_update": parseMexNotification(func(evt *events.NewsletterMetadataUpdate) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_newsletter_on_state_change": parseMexNotification(func(evt *events.NewsletterStateChange) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_group_on_participants_roles_change": parseMexNotification(func(evt *events.CommunityOwnerUpdate) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_group_on_participant_property_change": parseMexNotification(func(evt *events.GroupParticipantLabelUpdate) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_group_on_prop_change": parseMexNotification(func(evt *events.GroupPropertiesUpdate) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_account_reachout_timelock": parseMexNotification(func(evt *events.NotifyAccountReachoutTimelock) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_text_status_on_update": parseMexNotification(func(evt *events.TextStatusUpdate) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_username_on_change": parseMexNotification(func(evt *events.UsernameChange) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_username_delete": parseMexNotification(func(evt *events.UsernameDelete) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_username_on_update_side_sub": parseMexNotification(func(evt *events.OwnUsernameUpdate) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+	"xwa2_notify_wa_user": parseMexNotification(func(evt *events.AccountSyncUsername) *events.MexNotificationData {
+		return &evt.Mex
+	}),
+}
+
+// applyMexNotification updates local state based on parsed mex notifications before they're dispatched.
+func (cli *Client) applyMexNotification(ctx context.Context, evt any) {
+	switch typedEvt := evt.(type) {
+	case *events.UsernameChange:
+		cli.storeUsernameMapping(ctx, typedEvt.LID, NormalizeUsername(typedEvt.Username))
+	case *events.UsernameDelete:
+		cli.storeUsernameMapping(ctx, typedEvt.LID, "")
+		if typedEvt.LID.Server == types.HiddenUserServer && typedEvt.PhoneNumber.Server == types.DefaultUserServer {
+			err := cli.Store.LIDs.PutLIDMapping(ctx, typedEvt.LID, typedEvt.PhoneNumber)
+			if err != nil {
+				cli.Log.Warnf("Failed to store LID mapping for %s: %v", typedEvt.LID, err)
+			}
+		}
+	case *events.AccountSyncUsername:
+		if typedEvt.UsernameInfo != nil && typedEvt.LID.Server == types.HiddenUserServer {
+			cli.storeUsernameMapping(ctx, typedEvt.LID, NormalizeUsername(typedEvt.UsernameInfo.Username))
+		}
+	case *events.TextStatusUpdate:
+		cli.cacheTextStatuses(&typedEvt.TextStatus)
+	case *events.GroupParticipantLabelUpdate:
+		if typedEvt.UpdatedBy != nil && typedEvt.Update.Label != nil {
+			cli.groupCacheLock.Lock()
+			cli.groupCache.setLabel(typedEvt.Update.Group, typedEvt.Update.Label.Label, typedEvt.UpdatedBy.ID, typedEvt.UpdatedBy.PN)
+			cli.groupCacheLock.Unlock()
+		}
+	}
 }
 
-type newsletterEvent struct {
-	Join       *events.NewsletterJoin       `json:"xwa2_notify_newsletter_on_join"`
-	Leave      *events.NewsletterLeave      `json:"xwa2_notify_newsletter_on_leave"`
-	MuteChange *events.NewsletterMuteChange `json:"xwa2_notify_newsletter_on_mute_change"`
-	// _on_admin_metadata_update -> id, thread_metadata, messages
-	// _on_metadata_update
-	// _on_state_change -> id, is_requestor, state
-	NotifyAccountReachoutTimelock *events.NotifyAccountReachoutTimelock `json:"xwa2_notify_account_reachout_timelock"`
+type mexNotificationWrapper struct {
+	Data map[string]json.RawMessage `json:"data"`
 }
 
 func (cli *Client) handleMexNotification(ctx context.Context, node *waBinary.Node) {
@@ -407,32 +501,45 @@ func (cli *Client) handleMexNotification(ctx context.Context, node *waBinary.Nod
 		if child.Tag != "update" {
 			continue
 		}
+		cag := child.AttrGetter()
 		mnd := events.MexNotificationData{
 			Timestamp: node.AttrGetter().OptionalUnixTime("t"),
-			OpName:    child.AttrGetter().OptionalString("op_name"),
+			OpName:    cag.OptionalString("op_name"),
 		}
 		childData, ok := child.Content.([]byte)
 		if !ok {
 			continue
 		}
-		var wrapper newsLetterEventWrapper
-		err := json.Unmarshal(childData, &wrapper)
+		var err error
+		if cag.OptionalString("format") == "argo" {
+			childData, err = decodeArgoMexResponse(mnd.OpName, childData)
+			if err != nil {
+				cli.Log.Errorf("Failed to decode argo in mex event %s: %v", mnd.OpName, err)
+				continue
+			}
+		}
+		var wrapper mexNotificationWrapper
+		err = json.Unmarshal(childData, &wrapper)
 		if err != nil {
 			cli.Log.Errorf("Failed to unmarshal JSON in mex event: %v", err)
 			continue
 		}
-		if wrapper.Data.Join != nil {
-			wrapper.Data.Join.Mex = mnd
-			cli.dispatchEvent(wrapper.Data.Join)
-		} else if wrapper.Data.Leave != nil {
-			wrapper.Data.Leave.Mex = mnd
-			cli.dispatchEvent(wrapper.Data.Leave)
-		} else if wrapper.Data.MuteChange != nil {
-			wrapper.Data.MuteChange.Mex = mnd
-			cli.dispatchEvent(wrapper.Data.MuteChange)
-		} else if wrapper.Data.NotifyAccountReachoutTimelock != nil {
-			wrapper.Data.NotifyAccountReachoutTimelock.Mex = mnd
-			cli.dispatchEvent(wrapper.Data.NotifyAccountReachoutTimelock)
+		for field, data := range wrapper.Data {
+			if len(data) == 0 || string(data) == "null" {
+				continue
+			}
+			parser, ok := mexNotificationParsers[field]
+			if !ok {
+				cli.dispatchEvent(&events.MexNotification{Mex: mnd, Field: field, Data: data})
+				continue
+			}
+			evt, err := parser(data, mnd)
+			if err != nil {
+				cli.Log.Errorf("Failed to parse %s in mex event %s: %v", field, mnd.OpName, err)
+				continue
+			}
+			cli.applyMexNotification(ctx, evt)
+			cli.dispatchEvent(evt)
 		}
 	}
 }
@@ -476,7 +583,7 @@ func (cli *Client) handleNotification(ctx context.Context, node *waBinary.Node)
 	case "fbid:devices":
 		cli.handleFBDeviceNotification(ctx, node)
 	case "w:gp2":
-		evt, lidPairs, redactedPhones, err := cli.parseGroupNotification(node)
+		evt, lidPairs, redactedPhones, err := cli.parseGroupNotification(ctx, node)
 		if err != nil {
 			cli.Log.Errorf("Failed to parse group notification: %v", err)
 		} else {
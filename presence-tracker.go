@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// DefaultChatPresenceTimeout is the default time after which a composing chat presence is considered stale.
+const DefaultChatPresenceTimeout = 25 * time.Second
+
+// presenceResubscribeInterval is the delay between subscribe requests when resubscribing after connecting.
+const presenceResubscribeInterval = 20 * time.Millisecond
+
+// TrackPresence subscribes to the presence of the given users and remembers them,
+// so that the subscriptions are automatically renewed every time the client reconnects.
+//
+// Like with [Client.SubscribePresence], you need to mark yourself as online with [Client.SendPresence] to receive updates.
+// Errors from individual subscriptions are joined together, the users are tracked even if subscribing fails.
+//
+// Users without a stored privacy token are only resubscribed if [Client.ResubscribePresenceWithoutToken] is set.
+func (cli *Client) TrackPresence(ctx context.Context, jids ...types.JID) error {
+	if cli == nil {
+		return ErrClientIsNil
+	}
+	cli.presenceLock.Lock()
+	for _, jid := range jids {
+		cli.trackedPresences[jid.ToNonAD()] = struct{}{}
+	}
+	cli.presenceLock.Unlock()
+	if !cli.IsLoggedIn() {
+		return nil
+	}
+	var errs []error
+	for _, jid := range jids {
+		err := cli.SubscribePresence(ctx, jid.ToNonAD())
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UntrackPresence stops renewing presence subscriptions of the given users.
+// The server may keep sending updates until the next reconnect.
+func (cli *Client) UntrackPresence(jids ...types.JID) {
+	cli.presenceLock.Lock()
+	defer cli.presenceLock.Unlock()
+	for _, jid := range jids {
+		delete(cli.trackedPresences, jid.ToNonAD())
+	}
+}
+
+// GetTrackedPresences returns the list of users whose presence is tracked with [Client.TrackPresence].
+func (cli *Client) GetTrackedPresences() []types.JID {
+	cli.presenceLock.Lock()
+	defer cli.presenceLock.Unlock()
+	jids := make([]types.JID, 0, len(cli.trackedPresences))
+	for jid := range cli.trackedPresences {
+		jids = append(jids, jid)
+	}
+	return jids
+}
+
+func (cli *Client) resubscribePresences(ctx context.Context) {
+	jids := cli.GetTrackedPresences()
+	if len(jids) == 0 {
+		return
+	}
+	cli.Log.Debugf("Resubscribing to presence of %d tracked users", len(jids))
+	for i, jid := range jids {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(presenceResubscribeInterval):
+			}
+		}
+		cli.presenceLock.Lock()
+		_, stillTracked := cli.trackedPresences[jid]
+		cli.presenceLock.Unlock()
+		if !stillTracked {
+			continue
+		}
+		privacyToken, err := cli.ensureTCToken(ctx, jid)
+		if err != nil {
+			cli.Log.Warnf("Failed to get privacy token of %s for presence resubscription: %v", jid, err)
+			continue
+		} else if privacyToken == nil && (!cli.ResubscribePresenceWithoutToken || cli.ErrorOnSubscribePresenceWithoutToken) {
+			cli.Log.Debugf("Not resubscribing to presence of %s as there's no privacy token", jid)
+			continue
+		}
+		err = cli.sendPresenceSubscribe(ctx, jid, privacyToken)
+		if err != nil {
+			cli.Log.Warnf("Failed to resubscribe to presence of %s: %v", jid, err)
+			if !cli.IsConnected() {
+				return
+			}
+		}
+	}
+}
+
+// GetPresence returns the last known presence of the given user from presence updates received by this client.
+//
+// If the user isn't found with the given JID, the mapped LID or phone number JID is checked too.
+func (cli *Client) GetPresence(ctx context.Context, jid types.JID) (types.PresenceInfo, bool) {
+	jid = jid.ToNonAD()
+	cli.presenceLock.Lock()
+	info, ok := cli.presenceCache[jid]
+	cli.presenceLock.Unlock()
+	if ok {
+		return info, true
+	}
+	var altJID types.JID
+	var err error
+	switch jid.Server {
+	case types.DefaultUserServer:
+		altJID, err = cli.Store.LIDs.GetLIDForPN(ctx, jid)
+	case types.HiddenUserServer:
+		altJID, err = cli.Store.LIDs.GetPNForLID(ctx, jid)
+	}
+	if err != nil || altJID.IsEmpty() {
+		return types.PresenceInfo{}, false
+	}
+	cli.presenceLock.Lock()
+	info, ok = cli.presenceCache[altJID.ToNonAD()]
+	cli.presenceLock.Unlock()
+	return info, ok
+}
+
+func (cli *Client) cachePresence(evt *events.Presence) {
+	jid := evt.From.ToNonAD()
+	if jid.Server != types.DefaultUserServer && jid.Server != types.HiddenUserServer {
+		return
+	}
+	cli.presenceLock.Lock()
+	defer cli.presenceLock.Unlock()
+	info := cli.presenceCache[jid]
+	info.Online = !evt.Unavailable
+	if !evt.LastSeen.IsZero() {
+		info.LastSeen = evt.LastSeen
+	} else if evt.Unavailable {
+		info.LastSeen = time.Time{}
+	}
+	info.UpdatedAt = time.Now()
+	cli.presenceCache[jid] = info
+	if evt.Unavailable {
+		// Users who go offline can't be typing anymore
+		for chat, typing := range cli.chatTyping {
+			delete(typing, jid)
+			if len(typing) == 0 {
+				delete(cli.chatTyping, chat)
+			}
+		}
+	}
+}
+
+// GetTypingUsers returns the users who are currently typing or recording in the given chat, in the order they started.
+//
+// Composing states expire automatically after [Client.ChatPresenceTimeout] if no paused state or message is received.
+func (cli *Client) GetTypingUsers(chat types.JID) []types.TypingUser {
+	chat = chat.ToNonAD()
+	cutoff := time.Now().Add(-cli.getChatPresenceTimeout())
+	cli.presenceLock.Lock()
+	defer cli.presenceLock.Unlock()
+	typing := cli.chatTyping[chat]
+	users := make([]types.TypingUser, 0, len(typing))
+	for sender, user := range typing {
+		if user.UpdatedAt.Before(cutoff) {
+			delete(typing, sender)
+		} else {
+			users = append(users, user)
+		}
+	}
+	if len(typing) == 0 {
+		delete(cli.chatTyping, chat)
+	}
+	slices.SortFunc(users, func(a, b types.TypingUser) int {
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	})
+	return users
+}
+
+func (cli *Client) getChatPresenceTimeout() time.Duration {
+	if cli.ChatPresenceTimeout <= 0 {
+		return DefaultChatPresenceTimeout
+	}
+	return cli.ChatPresenceTimeout
+}
+
+func (cli *Client) updateChatTyping(source types.MessageSource, state types.ChatPresence, media types.ChatPresenceMedia) {
+	if state != types.ChatPresenceComposing {
+		cli.clearChatTyping(source.Chat, source.Sender)
+		return
+	}
+	chat := source.Chat.ToNonAD()
+	cutoff := time.Now().Add(-cli.getChatPresenceTimeout())
+	cli.presenceLock.Lock()
+	defer cli.presenceLock.Unlock()
+	for otherChat, typing := range cli.chatTyping {
+		for sender, user := range typing {
+			if user.UpdatedAt.Before(cutoff) {
+				delete(typing, sender)
+			}
+		}
+		if len(typing) == 0 {
+			delete(cli.chatTyping, otherChat)
+		}
+	}
+	typing, ok := cli.chatTyping[chat]
+	if !ok {
+		typing = make(map[types.JID]types.TypingUser)
+		cli.chatTyping[chat] = typing
+	}
+	sender := source.Sender.ToNonAD()
+	typing[sender] = types.TypingUser{
+		JID:       sender,
+		Media:     media,
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (cli *Client) clearChatTyping(chat, sender types.JID) {
+	chat = chat.ToNonAD()
+	cli.presenceLock.Lock()
+	defer cli.presenceLock.Unlock()
+	typing, ok := cli.chatTyping[chat]
+	if !ok {
+		return
+	}
+	delete(typing, sender.ToNonAD())
+	if len(typing) == 0 {
+		delete(cli.chatTyping, chat)
+	}
+}
@@ -28,6 +28,7 @@ func (cli *Client) handleChatState(ctx context.Context, node *waBinary.Node) {
 			cli.Log.Warnf("Unrecognized chat presence state %s", child.Tag)
 		}
 		media := types.ChatPresenceMedia(child.AttrGetter().OptionalString("media"))
+		cli.updateChatTyping(source, presence, media)
 		cli.dispatchEvent(&events.ChatPresence{
 			MessageSource: source,
 			State:         presence,
@@ -53,6 +54,7 @@ func (cli *Client) handlePresence(ctx context.Context, node *waBinary.Node) {
 	if !ag.OK() {
 		cli.Log.Warnf("Error parsing presence event: %+v", ag.Errors)
 	} else {
+		cli.cachePresence(&evt)
 		cli.dispatchEvent(&evt)
 	}
 }
@@ -80,10 +82,14 @@ func (cli *Client) SendPresence(ctx context.Context, state types.Presence) error
 	if cli.MessengerConfig == nil {
 		attrs["name"] = cli.Store.PushName
 	}
-	return cli.sendNode(ctx, waBinary.Node{
+	err := cli.sendNode(ctx, waBinary.Node{
 		Tag:   "presence",
 		Attrs: attrs,
 	})
+	if err == nil {
+		cli.presenceAvailable.Store(state == types.PresenceAvailable)
+	}
+	return err
 }
 
 // SubscribePresence asks the WhatsApp servers to send presence updates of a specific user to this client.
@@ -108,6 +114,10 @@ func (cli *Client) SubscribePresence(ctx context.Context, jid types.JID) error {
 			cli.Log.Debugf("Trying to subscribe to presence of %s without privacy token", jid)
 		}
 	}
+	return cli.sendPresenceSubscribe(ctx, jid, privacyToken)
+}
+
+func (cli *Client) sendPresenceSubscribe(ctx context.Context, jid types.JID, privacyToken []byte) error {
 	req := waBinary.Node{
 		Tag: "presence",
 		Attrs: waBinary.Attrs{
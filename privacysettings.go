@@ -7,7 +7,11 @@
 package whatsmeow
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
@@ -84,6 +88,12 @@ func (cli *Client) SetPrivacySetting(ctx context.Context, name types.PrivacySett
 		return settings, err
 	}
 	settings = *settingsPtr
+	setPrivacySettingValue(&settings, name, value)
+	cli.privacySettingsCache.Store(&settings)
+	return
+}
+
+func setPrivacySettingValue(settings *types.PrivacySettings, name types.PrivacySettingType, value types.PrivacySetting) {
 	switch name {
 	case types.PrivacySettingTypeGroupAdd:
 		settings.GroupAdd = value
@@ -99,9 +109,166 @@ func (cli *Client) SetPrivacySetting(ctx context.Context, name types.PrivacySett
 		settings.Online = value
 	case types.PrivacySettingTypeCallAdd:
 		settings.CallAdd = value
+	case types.PrivacySettingTypeMessages:
+		settings.Messages = value
+	case types.PrivacySettingTypeDefense:
+		settings.Defense = value
+	case types.PrivacySettingTypeStickers:
+		settings.Stickers = value
 	}
-	cli.privacySettingsCache.Store(&settings)
-	return
+}
+
+// GetPrivacyList gets the exception list of a privacy setting.
+//
+// The value specifies which list to get: PrivacySettingContactBlacklist for the contacts who are excluded,
+// or PrivacySettingContactAllowlist for the contacts who are included (only used for stickers).
+// The list is returned even if the setting currently has a different value.
+func (cli *Client) GetPrivacyList(ctx context.Context, name types.PrivacySettingType, value types.PrivacySetting) (*types.PrivacyList, error) {
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "privacy",
+		Type:      iqGet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "privacy",
+			Content: []waBinary.Node{{
+				Tag: "list",
+				Attrs: waBinary.Attrs{
+					"name":  string(name),
+					"value": string(value),
+				},
+			}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	listNode, ok := resp.GetOptionalChildByTag("privacy", "list")
+	if !ok {
+		return nil, &ElementMissingError{Tag: "list", In: "response to privacy list query"}
+	}
+	list := &types.PrivacyList{
+		Setting: name,
+		Value:   value,
+		DHash:   listNode.AttrGetter().OptionalString("dhash"),
+	}
+	for _, child := range listNode.GetChildrenByTag("user") {
+		jid, ok := child.Attrs["jid"].(types.JID)
+		if ok {
+			list.JIDs = append(list.JIDs, jid)
+		}
+	}
+	return list, nil
+}
+
+// UpdatePrivacyList adds and removes users in the exception list of a privacy setting and returns the updated list.
+//
+// This also changes the setting itself to the given value (PrivacySettingContactBlacklist or PrivacySettingContactAllowlist),
+// as the list is only used when the setting has that value.
+func (cli *Client) UpdatePrivacyList(ctx context.Context, name types.PrivacySettingType, value types.PrivacySetting, add, remove []types.JID) (*types.PrivacyList, error) {
+	return cli.doPrivacyListUpdate(ctx, name, value, func(current []types.JID) ([]types.JID, []types.JID) {
+		return add, remove
+	})
+}
+
+// SetPrivacyList replaces the exception list of a privacy setting with the given users and returns the updated list.
+// Like [Client.UpdatePrivacyList], this also changes the setting itself to the given value.
+func (cli *Client) SetPrivacyList(ctx context.Context, name types.PrivacySettingType, value types.PrivacySetting, jids []types.JID) (*types.PrivacyList, error) {
+	return cli.doPrivacyListUpdate(ctx, name, value, func(current []types.JID) (add, remove []types.JID) {
+		for _, jid := range jids {
+			if !slices.Contains(current, jid) {
+				add = append(add, jid)
+			}
+		}
+		for _, jid := range current {
+			if !slices.Contains(jids, jid) {
+				remove = append(remove, jid)
+			}
+		}
+		return
+	})
+}
+
+func (cli *Client) doPrivacyListUpdate(
+	ctx context.Context,
+	name types.PrivacySettingType,
+	value types.PrivacySetting,
+	getChanges func(current []types.JID) ([]types.JID, []types.JID),
+) (*types.PrivacyList, error) {
+	for attempt := 0; ; attempt++ {
+		list, err := cli.GetPrivacyList(ctx, name, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current list: %w", err)
+		}
+		add, remove := getChanges(list.JIDs)
+		list, err = cli.sendPrivacyListUpdate(ctx, list, add, remove)
+		// A conflict means the list was changed elsewhere after we fetched it, so try again with the new list
+		if errors.Is(err, ErrIQConflict) && attempt == 0 {
+			cli.Log.Debugf("Got conflict updating %s privacy list, refetching list and retrying", name)
+			continue
+		}
+		return list, err
+	}
+}
+
+func (cli *Client) sendPrivacyListUpdate(ctx context.Context, list *types.PrivacyList, add, remove []types.JID) (*types.PrivacyList, error) {
+	newJIDs := slices.Clone(list.JIDs)
+	users := make([]waBinary.Node, 0, len(add)+len(remove))
+	for _, jid := range add {
+		if !slices.Contains(newJIDs, jid) {
+			newJIDs = append(newJIDs, jid)
+			users = append(users, waBinary.Node{
+				Tag:   "user",
+				Attrs: waBinary.Attrs{"jid": jid, "action": "add"},
+			})
+		}
+	}
+	for _, jid := range remove {
+		if idx := slices.Index(newJIDs, jid); idx >= 0 {
+			newJIDs = slices.Delete(newJIDs, idx, idx+1)
+			users = append(users, waBinary.Node{
+				Tag:   "user",
+				Attrs: waBinary.Attrs{"jid": jid, "action": "remove"},
+			})
+		}
+	}
+	attrs := waBinary.Attrs{
+		"name":  string(list.Setting),
+		"value": string(list.Value),
+	}
+	if list.DHash != "" {
+		attrs["dhash"] = list.DHash
+	}
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "privacy",
+		Type:      iqSet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "privacy",
+			Content: []waBinary.Node{{
+				Tag:     "category",
+				Attrs:   attrs,
+				Content: users,
+			}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	newList := &types.PrivacyList{
+		Setting: list.Setting,
+		Value:   list.Value,
+		DHash:   list.DHash,
+		JIDs:    newJIDs,
+	}
+	if category, ok := resp.GetOptionalChildByTag("privacy", "category"); ok {
+		newList.DHash = cmp.Or(category.AttrGetter().OptionalString("dhash"), newList.DHash)
+	}
+	if val := cli.privacySettingsCache.Load(); val != nil {
+		settings := *val.(*types.PrivacySettings)
+		setPrivacySettingValue(&settings, list.Setting, list.Value)
+		cli.privacySettingsCache.Store(&settings)
+	}
+	return newList, nil
 }
 
 // SetDefaultDisappearingTimer will set the default disappearing message timer.
@@ -129,6 +296,24 @@ func (cli *Client) parsePrivacySettings(privacyNode *waBinary.Node, settings *ty
 		ag := child.AttrGetter()
 		name := types.PrivacySettingType(ag.String("name"))
 		value := types.PrivacySetting(ag.String("value"))
+		if users := child.GetChildrenByTag("user"); len(users) > 0 {
+			listChange := events.PrivacyListChange{
+				Setting: name,
+				Value:   value,
+				DHash:   ag.OptionalString("dhash"),
+			}
+			for _, user := range users {
+				uag := user.AttrGetter()
+				jid := uag.JID("jid")
+				switch uag.OptionalString("action") {
+				case "add":
+					listChange.Added = append(listChange.Added, jid)
+				case "remove":
+					listChange.Removed = append(listChange.Removed, jid)
+				}
+			}
+			evt.ListChanges = append(evt.ListChanges, listChange)
+		}
 		switch name {
 		case types.PrivacySettingTypeGroupAdd:
 			settings.GroupAdd = value
@@ -33,6 +33,7 @@ import (
 	"go.mau.fi/whatsmeow/proto/waAICommon"
 	"go.mau.fi/whatsmeow/proto/waCommon"
 	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
@@ -203,6 +204,11 @@ func (cli *Client) SendMessage(ctx context.Context, to types.JID, message *waE2E
 		err = ErrNotLoggedIn
 		return
 	}
+	if endsTypingSession(message) {
+		defer func() {
+			cli.endTypingSession(to, err != nil)
+		}()
+	}
 
 	if req.Timeout == 0 {
 		req.Timeout = defaultRequestTimeout
@@ -296,7 +302,7 @@ func (cli *Client) SendMessage(ctx context.Context, to types.JID, message *waE2E
 	if to.Server == types.GroupServer || to.Server == types.BroadcastServer {
 		start := time.Now()
 		if to.Server == types.GroupServer {
-			var cachedData *groupMetaCache
+			var cachedData *store.GroupMetadata
 			cachedData, err = cli.getCachedGroupData(ctx, to)
 			if err != nil {
 				err = fmt.Errorf("failed to get group members: %w", err)
@@ -457,9 +463,7 @@ func (cli *Client) SendMessage(ctx context.Context, to types.JID, message *waE2E
 		switch to.Server {
 		case types.GroupServer:
 			// TODO also invalidate device list caches
-			cli.groupCacheLock.Lock()
-			delete(cli.groupCache, to)
-			cli.groupCacheLock.Unlock()
+			cli.InvalidateGroupCache(ctx, to)
 		case types.BroadcastServer:
 			// TODO do something
 		case types.DefaultUserServer, types.HiddenUserServer, types.BotServer, types.HostedServer, types.HostedLIDServer:
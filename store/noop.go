@@ -26,20 +26,22 @@ var NoopDevice = &Device{
 	NoiseKey:    nilKey,
 	IdentityKey: nilKey,
 
-	Identities:    nilStore,
-	Sessions:      nilStore,
-	PreKeys:       nilStore,
-	SenderKeys:    nilStore,
-	AppStateKeys:  nilStore,
-	AppState:      nilStore,
-	Contacts:      nilStore,
-	ChatSettings:  nilStore,
-	MsgSecrets:    nilStore,
-	PrivacyTokens: nilStore,
-	NCTSalt:       nilStore,
-	EventBuffer:   nilStore,
-	LIDs:          nilStore,
-	Container:     nilStore,
+	Identities:       nilStore,
+	Sessions:         nilStore,
+	PreKeys:          nilStore,
+	SenderKeys:       nilStore,
+	AppStateKeys:     nilStore,
+	AppState:         nilStore,
+	Contacts:         nilStore,
+	ChatSettings:     nilStore,
+	MsgSecrets:       nilStore,
+	PrivacyTokens:    nilStore,
+	NCTSalt:          nilStore,
+	EventBuffer:      nilStore,
+	Groups:           nilStore,
+	ContactDiscovery: nilStore,
+	LIDs:             nilStore,
+	Container:        nilStore,
 }
 
 var _ AllStores = (*NoopStore)(nil)
@@ -189,6 +191,10 @@ func (n *NoopStore) GetContact(ctx context.Context, user types.JID) (types.Conta
 	return types.ContactInfo{}, n.Error
 }
 
+func (n *NoopStore) GetManyContacts(ctx context.Context, users []types.JID) (map[types.JID]types.ContactInfo, error) {
+	return nil, n.Error
+}
+
 func (n *NoopStore) GetAllContacts(ctx context.Context) (map[types.JID]types.ContactInfo, error) {
 	return nil, n.Error
 }
@@ -281,6 +287,10 @@ func (n *NoopStore) GetManyLIDsForPNs(ctx context.Context, pns []types.JID) (map
 	return nil, n.Error
 }
 
+func (n *NoopStore) GetManyPNsForLIDs(ctx context.Context, lids []types.JID) (map[types.JID]types.JID, error) {
+	return nil, n.Error
+}
+
 func (n *NoopStore) GetPNForLID(ctx context.Context, lid types.JID) (types.JID, error) {
 	return types.JID{}, n.Error
 }
@@ -293,6 +303,22 @@ func (n *NoopStore) PutLIDMapping(ctx context.Context, lid types.JID, jid types.
 	return n.Error
 }
 
+func (n *NoopStore) PutUsernameMapping(ctx context.Context, lid types.JID, username string) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetLIDForUsername(ctx context.Context, username string) (types.JID, error) {
+	return types.JID{}, n.Error
+}
+
+func (n *NoopStore) GetUsernameForLID(ctx context.Context, lid types.JID) (string, error) {
+	return "", n.Error
+}
+
+func (n *NoopStore) GetManyUsernamesForLIDs(ctx context.Context, lids []types.JID) (map[types.JID]string, error) {
+	return nil, n.Error
+}
+
 func (n *NoopStore) DeleteOldOutgoingEvents(ctx context.Context) error {
 	return nil
 }
@@ -304,3 +330,27 @@ func (n *NoopStore) GetOutgoingEvent(ctx context.Context, chatJID, altChatJID ty
 func (n *NoopStore) AddOutgoingEvent(ctx context.Context, chatJID types.JID, id types.MessageID, format string, plaintext []byte) error {
 	return nil
 }
+
+func (n *NoopStore) PutGroupMetadata(ctx context.Context, groups ...*GroupMetadata) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetGroupMetadata(ctx context.Context, group types.JID) (*GroupMetadata, error) {
+	return nil, n.Error
+}
+
+func (n *NoopStore) DeleteGroupMetadata(ctx context.Context, group types.JID) error {
+	return n.Error
+}
+
+func (n *NoopStore) PutContactDiscoveryResults(ctx context.Context, entries []ContactDiscoveryEntry) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetContactDiscoveryResults(ctx context.Context, phones []string) (map[string]ContactDiscoveryEntry, error) {
+	return nil, n.Error
+}
+
+func (n *NoopStore) DeleteOldContactDiscoveryResults(ctx context.Context, olderThan time.Time) error {
+	return n.Error
+}
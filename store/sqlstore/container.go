@@ -30,6 +30,11 @@ type Container struct {
 	db     *dbutil.Database
 	log    waLog.Logger
 	LIDMap *CachedLIDMap
+
+	// If true, group metadata (members and addressing mode) is stored in the database as Device.Groups,
+	// so it doesn't need to be fetched from the server again after restarting.
+	// This must be set before loading devices from the container. See Client.GroupCacheSize for more info.
+	PersistGroupMetadata bool
 }
 
 var _ store.DeviceContainer = (*Container)(nil)
@@ -257,6 +262,9 @@ func (c *Container) PutDevice(ctx context.Context, device *store.Device) error {
 func (c *Container) initializeDevice(device *store.Device) {
 	innerStore := NewSQLStore(c, *device.ID)
 	device.SetAllStores(innerStore)
+	if !c.PersistGroupMetadata {
+		device.Groups = nil
+	}
 	device.LIDs = c.LIDMap
 	device.Container = c
 	device.Initialized = true
@@ -136,29 +136,39 @@ func (s *CachedLIDMap) GetPNForLID(ctx context.Context, lid types.JID) (types.JI
 }
 
 func (s *CachedLIDMap) GetManyLIDsForPNs(ctx context.Context, pns []types.JID) (map[types.JID]types.JID, error) {
-	if len(pns) == 0 {
+	return s.getManyLIDMappings(ctx, pns, types.DefaultUserServer, types.HiddenUserServer, "pn", s.pnToLIDCache)
+}
+
+func (s *CachedLIDMap) GetManyPNsForLIDs(ctx context.Context, lids []types.JID) (map[types.JID]types.JID, error) {
+	return s.getManyLIDMappings(ctx, lids, types.HiddenUserServer, types.DefaultUserServer, "lid", s.lidToPNCache)
+}
+
+func (s *CachedLIDMap) getManyLIDMappings(
+	ctx context.Context, sources []types.JID, sourceServer, targetServer, sourceColumn string, sourceToTarget map[string]string,
+) (map[types.JID]types.JID, error) {
+	if len(sources) == 0 {
 		return nil, nil
 	}
 
-	result := make(map[types.JID]types.JID, len(pns))
+	result := make(map[types.JID]types.JID, len(sources))
 
 	s.lidCacheLock.RLock()
-	missingPNs := make([]string, 0, len(pns))
-	missingPNDevices := make(map[string][]types.JID)
-	for _, pn := range pns {
-		if pn.Server != types.DefaultUserServer {
+	missingUsers := make([]string, 0, len(sources))
+	missingDevices := make(map[string][]types.JID)
+	for _, source := range sources {
+		if source.Server != sourceServer {
 			continue
 		}
-		if lidUser, ok := s.pnToLIDCache[pn.User]; ok && lidUser != "" {
-			result[pn] = types.JID{User: lidUser, Device: pn.Device, Server: types.HiddenUserServer}
+		if targetUser, ok := sourceToTarget[source.User]; ok && targetUser != "" {
+			result[source] = types.JID{User: targetUser, Device: source.Device, Server: targetServer}
 		} else if !s.cacheFilled {
-			missingPNs = append(missingPNs, pn.User)
-			missingPNDevices[pn.User] = append(missingPNDevices[pn.User], pn)
+			missingUsers = append(missingUsers, source.User)
+			missingDevices[source.User] = append(missingDevices[source.User], source)
 		}
 	}
 	s.lidCacheLock.RUnlock()
 
-	if len(missingPNs) == 0 {
+	if len(missingUsers) == 0 {
 		return result, nil
 	}
 
@@ -169,26 +179,30 @@ func (s *CachedLIDMap) GetManyLIDsForPNs(ctx context.Context, pns []types.JID) (
 	if s.db.Dialect == dbutil.Postgres && PostgresArrayWrapper != nil {
 		res = convertLIDRow.NewRowIter(s.db.Query(
 			ctx,
-			`SELECT lid, pn FROM whatsmeow_lid_map WHERE pn = ANY($1)`,
-			PostgresArrayWrapper(missingPNs),
+			fmt.Sprintf(`SELECT lid, pn FROM whatsmeow_lid_map WHERE %s = ANY($1)`, sourceColumn),
+			PostgresArrayWrapper(missingUsers),
 		))
 	} else {
-		placeholders := make([]string, len(missingPNs))
-		for i := range missingPNs {
+		placeholders := make([]string, len(missingUsers))
+		for i := range missingUsers {
 			placeholders[i] = fmt.Sprintf("$%d", i+1)
 		}
 		res = convertLIDRow.NewRowIter(s.db.Query(
 			ctx,
-			fmt.Sprintf(`SELECT lid, pn FROM whatsmeow_lid_map WHERE pn IN (%s)`, strings.Join(placeholders, ",")),
-			exslices.CastToAny(missingPNs)...,
+			fmt.Sprintf(`SELECT lid, pn FROM whatsmeow_lid_map WHERE %s IN (%s)`, sourceColumn, strings.Join(placeholders, ",")),
+			exslices.CastToAny(missingUsers)...,
 		))
 	}
 	err := s.scanManyLids(res, func(lid, pn string) {
-		for _, dev := range missingPNDevices[pn] {
-			lidDev := dev
-			lidDev.Server = types.HiddenUserServer
-			lidDev.User = lid
-			result[dev] = lidDev
+		sourceUser, targetUser := pn, lid
+		if sourceServer == types.HiddenUserServer {
+			sourceUser, targetUser = lid, pn
+		}
+		for _, dev := range missingDevices[sourceUser] {
+			targetDev := dev
+			targetDev.Server = targetServer
+			targetDev.User = targetUser
+			result[dev] = targetDev
 		}
 	})
 	return result, err
@@ -265,3 +279,96 @@ func (s *CachedLIDMap) unlockedPutLIDMapping(ctx context.Context, lid, pn types.
 	}
 	return nil
 }
+
+const (
+	deleteExistingUsernameQuery = `DELETE FROM whatsmeow_lid_username WHERE lid=$1 OR username=$2`
+	putUsernameMappingQuery     = `INSERT INTO whatsmeow_lid_username (lid, username) VALUES ($1, $2)`
+	deleteUsernameMappingQuery  = `DELETE FROM whatsmeow_lid_username WHERE lid=$1`
+	getLIDForUsernameQuery      = `SELECT lid FROM whatsmeow_lid_username WHERE username=$1`
+	getUsernameForLIDQuery      = `SELECT username FROM whatsmeow_lid_username WHERE lid=$1`
+
+	getManyUsernamesQueryPostgres = `SELECT lid, username FROM whatsmeow_lid_username WHERE lid = ANY($1)`
+	getManyUsernamesQueryGeneric  = `SELECT lid, username FROM whatsmeow_lid_username WHERE lid IN (%s)`
+)
+
+type lidUsernameTuple struct {
+	LID      string
+	Username string
+}
+
+var convertUsernameRow = dbutil.ConvertRowFn[lidUsernameTuple](func(rows dbutil.Scannable) (tuple lidUsernameTuple, err error) {
+	err = rows.Scan(&tuple.LID, &tuple.Username)
+	return
+})
+
+func (s *CachedLIDMap) PutUsernameMapping(ctx context.Context, lid types.JID, username string) error {
+	if lid.Server != types.HiddenUserServer {
+		return fmt.Errorf("invalid PutUsernameMapping call with non-LID JID %s", lid)
+	} else if username == "" {
+		_, err := s.db.Exec(ctx, deleteUsernameMappingQuery, lid.User)
+		return err
+	}
+	return s.db.DoTxn(ctx, nil, func(ctx context.Context) error {
+		_, err := s.db.Exec(ctx, deleteExistingUsernameQuery, lid.User, username)
+		if err != nil {
+			return err
+		}
+		_, err = s.db.Exec(ctx, putUsernameMappingQuery, lid.User, username)
+		return err
+	})
+}
+
+func (s *CachedLIDMap) GetLIDForUsername(ctx context.Context, username string) (types.JID, error) {
+	var lidUser string
+	err := s.db.QueryRow(ctx, getLIDForUsernameQuery, username).Scan(&lidUser)
+	if errors.Is(err, sql.ErrNoRows) {
+		return types.JID{}, nil
+	} else if err != nil {
+		return types.JID{}, err
+	}
+	return types.JID{User: lidUser, Server: types.HiddenUserServer}, nil
+}
+
+func (s *CachedLIDMap) GetUsernameForLID(ctx context.Context, lid types.JID) (string, error) {
+	if lid.Server != types.HiddenUserServer {
+		return "", fmt.Errorf("invalid GetUsernameForLID call with non-LID JID %s", lid)
+	}
+	var username string
+	err := s.db.QueryRow(ctx, getUsernameForLIDQuery, lid.User).Scan(&username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return username, err
+}
+
+func (s *CachedLIDMap) GetManyUsernamesForLIDs(ctx context.Context, lids []types.JID) (map[types.JID]string, error) {
+	lidUsers := make([]string, 0, len(lids))
+	for _, lid := range lids {
+		if lid.Server == types.HiddenUserServer {
+			lidUsers = append(lidUsers, lid.User)
+		}
+	}
+	if len(lidUsers) == 0 {
+		return nil, nil
+	}
+	var res dbutil.RowIter[lidUsernameTuple]
+	if s.db.Dialect == dbutil.Postgres && PostgresArrayWrapper != nil {
+		res = convertUsernameRow.NewRowIter(s.db.Query(ctx, getManyUsernamesQueryPostgres, PostgresArrayWrapper(lidUsers)))
+	} else {
+		placeholders := make([]string, len(lidUsers))
+		for i := range lidUsers {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		}
+		res = convertUsernameRow.NewRowIter(s.db.Query(
+			ctx,
+			fmt.Sprintf(getManyUsernamesQueryGeneric, strings.Join(placeholders, ",")),
+			exslices.CastToAny(lidUsers)...,
+		))
+	}
+	result := make(map[types.JID]string, len(lidUsers))
+	err := res.Iter(func(tuple lidUsernameTuple) (bool, error) {
+		result[types.JID{User: tuple.LID, Server: types.HiddenUserServer}] = tuple.Username
+		return true, nil
+	})
+	return result, err
+}
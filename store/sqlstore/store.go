@@ -11,6 +11,7 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
@@ -604,6 +605,12 @@ const (
 	getAllContactsQuery = `
 		SELECT their_jid, first_name, full_name, push_name, business_name, redacted_phone FROM whatsmeow_contacts WHERE our_jid=$1
 	`
+	getManyContactsQueryPostgres = `
+		SELECT their_jid, first_name, full_name, push_name, business_name, redacted_phone FROM whatsmeow_contacts WHERE our_jid=$1 AND their_jid = ANY($2)
+	`
+	getManyContactsQueryGeneric = `
+		SELECT their_jid, first_name, full_name, push_name, business_name, redacted_phone FROM whatsmeow_contacts WHERE our_jid=$1 AND their_jid IN (%s)
+	`
 )
 
 var putContactNamesMassInsertBuilder = dbutil.NewMassInsertBuilder[store.ContactEntry, [1]any](
@@ -802,6 +809,58 @@ var convertContactRow = dbutil.ConvertRowFn[*contactTuple](func(rows dbutil.Scan
 	}, nil
 })
 
+func (s *SQLStore) GetManyContacts(ctx context.Context, users []types.JID) (map[types.JID]types.ContactInfo, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+	s.contactCacheLock.Lock()
+	defer s.contactCacheLock.Unlock()
+	output := make(map[types.JID]types.ContactInfo, len(users))
+	missing := make([]string, 0, len(users))
+	for _, user := range users {
+		if cached, ok := s.contactCache[user]; ok {
+			output[user] = *cached
+		} else {
+			missing = append(missing, user.String())
+		}
+	}
+	if len(missing) == 0 {
+		return output, nil
+	}
+	slices.Sort(missing)
+	missing = slices.Compact(missing)
+	var rows dbutil.Rows
+	var err error
+	if s.db.Dialect == dbutil.Postgres && PostgresArrayWrapper != nil {
+		rows, err = s.db.Query(ctx, getManyContactsQueryPostgres, s.JID, PostgresArrayWrapper(missing))
+	} else {
+		args := make([]any, len(missing)+1)
+		placeholders := make([]string, len(missing))
+		args[0] = s.JID
+		for i, user := range missing {
+			args[i+1] = user
+			placeholders[i] = fmt.Sprintf("$%d", i+2)
+		}
+		rows, err = s.db.Query(ctx, fmt.Sprintf(getManyContactsQueryGeneric, strings.Join(placeholders, ",")), args...)
+	}
+	err = convertContactRow.NewRowIter(rows, err).Iter(func(tuple *contactTuple) (bool, error) {
+		output[tuple.JID] = *tuple.Info
+		s.contactCache[tuple.JID] = tuple.Info
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if _, ok := output[user]; !ok {
+			info := &types.ContactInfo{}
+			s.contactCache[user] = info
+			output[user] = *info
+		}
+	}
+	return output, nil
+}
+
 func (s *SQLStore) GetAllContacts(ctx context.Context) (map[types.JID]types.ContactInfo, error) {
 	s.contactCacheLock.Lock()
 	defer s.contactCacheLock.Unlock()
@@ -1108,3 +1167,165 @@ func (s *SQLStore) DeleteOldOutgoingEvents(ctx context.Context) error {
 	_, err := s.db.Exec(ctx, deleteOldOutgoingEventsQuery, s.JID, time.Now().Add(-7*24*time.Hour).UnixMilli())
 	return err
 }
+
+const (
+	putGroupMetadataQuery = `
+		INSERT INTO whatsmeow_group_metadata (
+			our_jid, group_jid, addressing_mode, community_announcement_group, participant_version_id, members
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (our_jid, group_jid) DO UPDATE SET
+			addressing_mode=excluded.addressing_mode,
+			community_announcement_group=excluded.community_announcement_group,
+			participant_version_id=excluded.participant_version_id,
+			members=excluded.members
+	`
+	getGroupMetadataQuery = `
+		SELECT addressing_mode, community_announcement_group, participant_version_id, members
+		FROM whatsmeow_group_metadata WHERE our_jid=$1 AND group_jid=$2
+	`
+	deleteGroupMetadataQuery = `DELETE FROM whatsmeow_group_metadata WHERE our_jid=$1 AND group_jid=$2`
+)
+
+func (s *SQLStore) putGroupMetadata(ctx context.Context, group *store.GroupMetadata) error {
+	members, err := json.Marshal(group.Members)
+	if err != nil {
+		return fmt.Errorf("failed to marshal members of %s: %w", group.JID, err)
+	}
+	_, err = s.db.Exec(
+		ctx, putGroupMetadataQuery, s.JID, group.JID,
+		group.AddressingMode, group.CommunityAnnouncementGroup, group.ParticipantVersionID, string(members),
+	)
+	return err
+}
+
+func (s *SQLStore) PutGroupMetadata(ctx context.Context, groups ...*store.GroupMetadata) error {
+	if len(groups) == 1 {
+		return s.putGroupMetadata(ctx, groups[0])
+	}
+	return s.db.DoTxn(ctx, nil, func(ctx context.Context) error {
+		for _, group := range groups {
+			err := s.putGroupMetadata(ctx, group)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLStore) GetGroupMetadata(ctx context.Context, group types.JID) (*store.GroupMetadata, error) {
+	meta := store.GroupMetadata{JID: group}
+	var members []byte
+	err := s.db.QueryRow(ctx, getGroupMetadataQuery, s.JID, group).Scan(
+		&meta.AddressingMode, &meta.CommunityAnnouncementGroup, &meta.ParticipantVersionID, &members,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(members, &meta.Members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal members: %w", err)
+	}
+	return &meta, nil
+}
+
+func (s *SQLStore) DeleteGroupMetadata(ctx context.Context, group types.JID) error {
+	_, err := s.db.Exec(ctx, deleteGroupMetadataQuery, s.JID, group)
+	return err
+}
+
+const (
+	putContactDiscoveryResultQuery = `
+		INSERT INTO whatsmeow_contact_discovery (our_jid, phone_number, is_in, jid, checked_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (our_jid, phone_number) DO UPDATE SET is_in=excluded.is_in, jid=excluded.jid, checked_at=excluded.checked_at
+	`
+	getContactDiscoveryResultsQueryPostgres = `
+		SELECT phone_number, is_in, jid, checked_at FROM whatsmeow_contact_discovery WHERE our_jid=$1 AND phone_number = ANY($2)
+	`
+	getContactDiscoveryResultsQueryGeneric = `
+		SELECT phone_number, is_in, jid, checked_at FROM whatsmeow_contact_discovery WHERE our_jid=$1 AND phone_number IN (%s)
+	`
+	deleteOldContactDiscoveryResultsQuery = `DELETE FROM whatsmeow_contact_discovery WHERE our_jid=$1 AND checked_at<$2`
+)
+
+var putContactDiscoveryResultsMassInsertBuilder = dbutil.NewMassInsertBuilder[store.ContactDiscoveryEntry, [1]any](
+	putContactDiscoveryResultQuery, "($1, $%d, $%d, $%d, $%d)",
+)
+
+func (s *SQLStore) PutContactDiscoveryResults(ctx context.Context, entries []store.ContactDiscoveryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	entries = exslices.DeduplicateUnsortedOverwriteFunc(entries, func(t store.ContactDiscoveryEntry) string {
+		return t.PhoneNumber
+	})
+	return s.db.DoTxn(ctx, nil, func(ctx context.Context) error {
+		for slice := range slices.Chunk(entries, contactBatchSize) {
+			query, vars := putContactDiscoveryResultsMassInsertBuilder.Build([1]any{s.JID}, slice)
+			_, err := s.db.Exec(ctx, query, vars...)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+var scanContactDiscoveryEntry = dbutil.ConvertRowFn[store.ContactDiscoveryEntry](func(row dbutil.Scannable) (entry store.ContactDiscoveryEntry, err error) {
+	var jid string
+	var checkedAt int64
+	err = row.Scan(&entry.PhoneNumber, &entry.IsIn, &jid, &checkedAt)
+	if err != nil {
+		return
+	}
+	if jid != "" {
+		entry.JID, err = types.ParseJID(jid)
+		if err != nil {
+			return entry, fmt.Errorf("failed to parse JID of %s: %w", entry.PhoneNumber, err)
+		}
+	}
+	entry.CheckedAt = time.UnixMilli(checkedAt)
+	return
+})
+
+func (s *SQLStore) GetContactDiscoveryResults(ctx context.Context, phones []string) (map[string]store.ContactDiscoveryEntry, error) {
+	if len(phones) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]store.ContactDiscoveryEntry, len(phones))
+	collect := func(entry store.ContactDiscoveryEntry) (bool, error) {
+		result[entry.PhoneNumber] = entry
+		return true, nil
+	}
+	if s.db.Dialect == dbutil.Postgres && PostgresArrayWrapper != nil {
+		err := scanContactDiscoveryEntry.NewRowIter(
+			s.db.Query(ctx, getContactDiscoveryResultsQueryPostgres, s.JID, PostgresArrayWrapper(phones)),
+		).Iter(collect)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	for chunk := range slices.Chunk(phones, contactBatchSize) {
+		placeholders := make([]string, len(chunk))
+		args := make([]any, len(chunk)+1)
+		args[0] = s.JID
+		for i, phone := range chunk {
+			placeholders[i] = fmt.Sprintf("$%d", i+2)
+			args[i+1] = phone
+		}
+		query := fmt.Sprintf(getContactDiscoveryResultsQueryGeneric, strings.Join(placeholders, ","))
+		err := scanContactDiscoveryEntry.NewRowIter(s.db.Query(ctx, query, args...)).Iter(collect)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *SQLStore) DeleteOldContactDiscoveryResults(ctx context.Context, olderThan time.Time) error {
+	_, err := s.db.Exec(ctx, deleteOldContactDiscoveryResultsQuery, s.JID, olderThan.UnixMilli())
+	return err
+}
@@ -1,4 +1,4 @@
--- v0 -> v15 (compatible with v8+): Latest schema
+-- v0 -> v18 (compatible with v8+): Latest schema
 CREATE TABLE whatsmeow_device (
 	jid TEXT PRIMARY KEY,
 	lid TEXT,
@@ -152,11 +152,28 @@ CREATE TABLE whatsmeow_nct_salt (
 	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
+CREATE TABLE whatsmeow_group_metadata (
+	our_jid                      TEXT    NOT NULL,
+	group_jid                    TEXT    NOT NULL,
+	addressing_mode              TEXT    NOT NULL,
+	community_announcement_group BOOLEAN NOT NULL,
+	participant_version_id       TEXT    NOT NULL,
+	members                      TEXT    NOT NULL,
+
+	PRIMARY KEY (our_jid, group_jid),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 CREATE TABLE whatsmeow_lid_map (
 	lid TEXT PRIMARY KEY,
 	pn  TEXT UNIQUE NOT NULL
 );
 
+CREATE TABLE whatsmeow_lid_username (
+	lid      TEXT PRIMARY KEY,
+	username TEXT UNIQUE NOT NULL
+);
+
 CREATE TABLE whatsmeow_event_buffer (
 	our_jid          TEXT   NOT NULL,
 	ciphertext_hash  bytea  NOT NULL CHECK ( length(ciphertext_hash) = 32 ),
@@ -180,3 +197,14 @@ CREATE TABLE whatsmeow_retry_buffer (
 );
 
 CREATE INDEX whatsmeow_retry_buffer_timestamp_idx ON whatsmeow_retry_buffer (our_jid, timestamp);
+
+CREATE TABLE whatsmeow_contact_discovery (
+	our_jid      TEXT    NOT NULL,
+	phone_number TEXT    NOT NULL,
+	is_in        BOOLEAN NOT NULL,
+	jid          TEXT    NOT NULL,
+	checked_at   BIGINT  NOT NULL,
+
+	PRIMARY KEY (our_jid, phone_number),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,12 @@
+-- v16 (compatible with v8+): Add table for cached group metadata
+CREATE TABLE whatsmeow_group_metadata (
+	our_jid                      TEXT    NOT NULL,
+	group_jid                    TEXT    NOT NULL,
+	addressing_mode              TEXT    NOT NULL,
+	community_announcement_group BOOLEAN NOT NULL,
+	participant_version_id       TEXT    NOT NULL,
+	members                      TEXT    NOT NULL,
+
+	PRIMARY KEY (our_jid, group_jid),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,5 @@
+-- v17 (compatible with v8+): Add table for usernames of LID users
+CREATE TABLE whatsmeow_lid_username (
+	lid      TEXT PRIMARY KEY,
+	username TEXT UNIQUE NOT NULL
+);
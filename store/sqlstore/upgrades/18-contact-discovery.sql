@@ -0,0 +1,11 @@
+-- v18 (compatible with v8+): Add table for cached contact discovery results
+CREATE TABLE whatsmeow_contact_discovery (
+	our_jid      TEXT    NOT NULL,
+	phone_number TEXT    NOT NULL,
+	is_in        BOOLEAN NOT NULL,
+	jid          TEXT    NOT NULL,
+	checked_at   BIGINT  NOT NULL,
+
+	PRIMARY KEY (our_jid, phone_number),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -106,6 +106,7 @@ type ContactStore interface {
 	PutAllContactNames(ctx context.Context, contacts []ContactEntry) error
 	PutManyRedactedPhones(ctx context.Context, entries []RedactedPhoneEntry) error
 	GetContact(ctx context.Context, user types.JID) (types.ContactInfo, error)
+	GetManyContacts(ctx context.Context, users []types.JID) (map[types.JID]types.ContactInfo, error)
 	GetAllContacts(ctx context.Context) (map[types.JID]types.ContactInfo, error)
 }
 
@@ -155,6 +156,45 @@ type NCTSaltStore interface {
 	DeleteNCTSalt(ctx context.Context) error
 }
 
+// GroupMetadata contains the parts of a group's info that are needed for sending messages to the group.
+type GroupMetadata struct {
+	JID                        types.JID
+	AddressingMode             types.AddressingMode
+	CommunityAnnouncementGroup bool
+	ParticipantVersionID       string
+	Members                    []types.JID
+}
+
+// GroupStore persists group metadata, so that it doesn't need to be fetched again after restarting.
+//
+// Unlike the other stores, it's optional. Device.Groups is nil unless explicitly enabled
+// (e.g. using sqlstore.Container.PersistGroupMetadata), and group metadata is only cached in memory if it's nil.
+type GroupStore interface {
+	PutGroupMetadata(ctx context.Context, groups ...*GroupMetadata) error
+	GetGroupMetadata(ctx context.Context, group types.JID) (*GroupMetadata, error)
+	DeleteGroupMetadata(ctx context.Context, group types.JID) error
+}
+
+// ContactDiscoveryEntry is a cached result of checking whether a phone number is registered on WhatsApp.
+type ContactDiscoveryEntry struct {
+	// The phone number in international format without the + prefix.
+	PhoneNumber string
+	IsIn        bool
+	// The JID returned by the server, usually a LID for registered users.
+	JID       types.JID
+	CheckedAt time.Time
+}
+
+func (cde ContactDiscoveryEntry) GetMassInsertValues() [4]any {
+	return [...]any{cde.PhoneNumber, cde.IsIn, cde.JID.String(), cde.CheckedAt.UnixMilli()}
+}
+
+type ContactDiscoveryStore interface {
+	PutContactDiscoveryResults(ctx context.Context, entries []ContactDiscoveryEntry) error
+	GetContactDiscoveryResults(ctx context.Context, phones []string) (map[string]ContactDiscoveryEntry, error)
+	DeleteOldContactDiscoveryResults(ctx context.Context, olderThan time.Time) error
+}
+
 type BufferedEvent struct {
 	Plaintext  []byte
 	InsertTime time.Time
@@ -188,6 +228,14 @@ type LIDStore interface {
 	GetPNForLID(ctx context.Context, lid types.JID) (types.JID, error)
 	GetLIDForPN(ctx context.Context, pn types.JID) (types.JID, error)
 	GetManyLIDsForPNs(ctx context.Context, pns []types.JID) (map[types.JID]types.JID, error)
+	GetManyPNsForLIDs(ctx context.Context, lids []types.JID) (map[types.JID]types.JID, error)
+
+	// PutUsernameMapping stores the username of a LID user. Usernames are unique, so any other LID with the same
+	// username should be forgotten. An empty username deletes the mapping.
+	PutUsernameMapping(ctx context.Context, lid types.JID, username string) error
+	GetLIDForUsername(ctx context.Context, username string) (types.JID, error)
+	GetUsernameForLID(ctx context.Context, lid types.JID) (string, error)
+	GetManyUsernamesForLIDs(ctx context.Context, lids []types.JID) (map[types.JID]string, error)
 }
 
 type AllSessionSpecificStores interface {
@@ -203,6 +251,8 @@ type AllSessionSpecificStores interface {
 	PrivacyTokenStore
 	NCTSaltStore
 	EventBuffer
+	GroupStore
+	ContactDiscoveryStore
 }
 
 type AllGlobalStores interface {
@@ -236,22 +286,24 @@ type Device struct {
 
 	FacebookUUID uuid.UUID
 
-	Initialized   bool
-	Deleted       bool
-	Identities    IdentityStore
-	Sessions      SessionStore
-	PreKeys       PreKeyStore
-	SenderKeys    SenderKeyStore
-	AppStateKeys  AppStateSyncKeyStore
-	AppState      AppStateStore
-	Contacts      ContactStore
-	ChatSettings  ChatSettingsStore
-	MsgSecrets    MsgSecretStore
-	PrivacyTokens PrivacyTokenStore
-	NCTSalt       NCTSaltStore
-	EventBuffer   EventBuffer
-	LIDs          LIDStore
-	Container     DeviceContainer
+	Initialized      bool
+	Deleted          bool
+	Identities       IdentityStore
+	Sessions         SessionStore
+	PreKeys          PreKeyStore
+	SenderKeys       SenderKeyStore
+	AppStateKeys     AppStateSyncKeyStore
+	AppState         AppStateStore
+	Contacts         ContactStore
+	ChatSettings     ChatSettingsStore
+	MsgSecrets       MsgSecretStore
+	PrivacyTokens    PrivacyTokenStore
+	NCTSalt          NCTSaltStore
+	EventBuffer      EventBuffer
+	Groups           GroupStore
+	ContactDiscovery ContactDiscoveryStore
+	LIDs             LIDStore
+	Container        DeviceContainer
 }
 
 func (device *Device) GetJID() types.JID {
@@ -309,6 +361,8 @@ func (device *Device) SetAllStores(store AllSessionSpecificStores) {
 	device.PrivacyTokens = store
 	device.NCTSalt = store
 	device.EventBuffer = store
+	device.Groups = store
+	device.ContactDiscovery = store
 }
 
 func (device *Device) GetAltJID(ctx context.Context, jid types.JID) (types.JID, error) {
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// maxTextStatusCacheSize is the maximum number of text statuses kept in memory.
+// When it's exceeded, the least recently updated statuses are dropped until the cache is 10% below the limit.
+const maxTextStatusCacheSize = 5000
+
+type respGetTextStatusList struct {
+	Statuses []*types.TextStatus `json:"xwa2_text_status_list"`
+}
+
+// GetTextStatuses gets the current text statuses (about) of the given users.
+//
+// Users who don't have a status, whose status has expired, or whose status isn't visible to you
+// are not included in the returned map. The results are also cached, see [Client.GetCachedTextStatus].
+func (cli *Client) GetTextStatuses(ctx context.Context, jids []types.JID) (map[types.JID]*types.TextStatus, error) {
+	jidStrings := make([]string, len(jids))
+	for i, jid := range jids {
+		jidStrings[i] = jid.ToNonAD().String()
+	}
+	resp, err := MexQuery[respGetTextStatusList](ctx, cli, "GetTextStatusList", map[string]any{
+		"input": map[string]any{
+			"jids": jidStrings,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	cli.cacheTextStatuses(resp.Statuses...)
+	now := time.Now()
+	output := make(map[types.JID]*types.TextStatus, len(resp.Statuses))
+	for _, status := range resp.Statuses {
+		if status != nil && !status.IsExpired(now) && (status.Text != "" || status.Emoji != nil) {
+			output[status.JID.ToNonAD()] = status
+		}
+	}
+	return output, nil
+}
+
+// GetCachedTextStatus returns the last known text status of the given user from [Client.GetTextStatuses]
+// and [events.TextStatusUpdate]. Expired statuses are removed from the cache, so this returns nil for them.
+// Only the most recently updated statuses are kept, so this may also return nil for old statuses.
+func (cli *Client) GetCachedTextStatus(jid types.JID) *types.TextStatus {
+	jid = jid.ToNonAD()
+	cli.textStatusCacheLock.Lock()
+	defer cli.textStatusCacheLock.Unlock()
+	status, ok := cli.textStatusCache[jid]
+	if ok && status.IsExpired(time.Now()) {
+		delete(cli.textStatusCache, jid)
+		return nil
+	}
+	return status
+}
+
+func (cli *Client) cacheTextStatuses(statuses ...*types.TextStatus) {
+	now := time.Now()
+	cli.textStatusCacheLock.Lock()
+	defer cli.textStatusCacheLock.Unlock()
+	for jid, status := range cli.textStatusCache {
+		if status.IsExpired(now) {
+			delete(cli.textStatusCache, jid)
+		}
+	}
+	for _, status := range statuses {
+		if status == nil || status.JID.IsEmpty() {
+			continue
+		}
+		jid := status.JID.ToNonAD()
+		if status.IsExpired(now) || (status.Text == "" && status.Emoji == nil) {
+			delete(cli.textStatusCache, jid)
+		} else if existing, ok := cli.textStatusCache[jid]; !ok || !existing.LastUpdate.After(status.LastUpdate.Time) {
+			cli.textStatusCache[jid] = status
+		}
+	}
+	if len(cli.textStatusCache) > maxTextStatusCacheSize {
+		cached := slices.Collect(maps.Values(cli.textStatusCache))
+		slices.SortFunc(cached, func(a, b *types.TextStatus) int {
+			return a.LastUpdate.Compare(b.LastUpdate.Time)
+		})
+		for _, status := range cached[:len(cached)-maxTextStatusCacheSize*9/10] {
+			delete(cli.textStatusCache, status.JID.ToNonAD())
+		}
+	}
+}
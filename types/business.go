@@ -37,3 +37,84 @@ type OrderPrice struct {
 	Currency    string `json:"currency"`
 	PriceStatus string `json:"price_status,omitempty"`
 }
+
+// CatalogProduct is a product in the catalog of a WhatsApp business.
+type CatalogProduct struct {
+	ID          string `json:"id"`
+	RetailerID  string `json:"retailer_id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	// The price in thousandths of the currency unit, e.g. 12500 means 12.50.
+	Price    int64  `json:"price,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	IsHidden bool   `json:"is_hidden,omitempty"`
+	// The URLs of the product images, the first one is the main image.
+	ImageURLs []string `json:"image_urls,omitempty"`
+	// The review status of the product, e.g. "APPROVED", "PENDING" or "REJECTED".
+	ReviewStatus string `json:"review_status,omitempty"`
+	// The two-letter code of the country where the product was made, if set.
+	OriginCountryCode string `json:"origin_country_code,omitempty"`
+}
+
+// CatalogPage is one page of products in the catalog of a WhatsApp business.
+type CatalogPage struct {
+	Products []*CatalogProduct `json:"products"`
+	// The cursor to pass to GetCatalog to get the next page, empty if there are no more products.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// CatalogCollection is a named group of products in the catalog of a WhatsApp business.
+type CatalogCollection struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	ReviewStatus string            `json:"review_status,omitempty"`
+	Products     []*CatalogProduct `json:"products"`
+}
+
+// ProductMessageInfo contains the parsed contents of a product or catalog message.
+type ProductMessageInfo struct {
+	// The business that owns the product or catalog.
+	BusinessOwner JID `json:"business_owner"`
+	// The shared product. Only the fields included in the message are set, and ImageURLs is always empty.
+	// Nil if the message shares a whole catalog.
+	Product *CatalogProduct `json:"product,omitempty"`
+	// The number of images the product has in the catalog.
+	ProductImageCount int `json:"product_image_count,omitempty"`
+	// The title and description of the shared catalog, if the message shares a whole catalog.
+	CatalogTitle       string `json:"catalog_title,omitempty"`
+	CatalogDescription string `json:"catalog_description,omitempty"`
+	Body               string `json:"body,omitempty"`
+	Footer             string `json:"footer,omitempty"`
+}
+
+// Order is the structured form of an order message, with line items resolved from the order details.
+type Order struct {
+	ID        string    `json:"id"`
+	Seller    JID       `json:"seller"`
+	Title     string    `json:"title,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	CatalogID string    `json:"catalog_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Items []OrderLineItem `json:"items"`
+	// The amounts are in thousandths of the currency unit, e.g. 12500 means 12.50.
+	Subtotal    int64  `json:"subtotal"`
+	Total       int64  `json:"total"`
+	Currency    string `json:"currency"`
+	PriceStatus string `json:"price_status,omitempty"`
+}
+
+// OrderLineItem is a single product in an [Order].
+type OrderLineItem struct {
+	ProductID         string `json:"product_id"`
+	Name              string `json:"name"`
+	ImageURL          string `json:"image_url,omitempty"`
+	VariantProperties string `json:"variant_properties,omitempty"`
+	Quantity          int    `json:"quantity"`
+	// The price of one item and of the whole line in thousandths of the currency unit.
+	UnitPrice int64  `json:"unit_price"`
+	LineTotal int64  `json:"line_total"`
+	Currency  string `json:"currency"`
+}
@@ -8,6 +8,7 @@
 package events
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -326,6 +327,8 @@ type Message struct {
 	IsLottieSticker       bool // True if the message was unwrapped from a LottieStickerMessage
 	IsBotInvoke           bool // True if the message was unwrapped from a BotInvokeMessage
 	IsEdit                bool // True if the message was unwrapped from an EditedMessage
+	IsAssociatedChild     bool // True if the message was unwrapped from an AssociatedChildMessage (e.g. an album item)
+	IsSharedHistory       bool // True if the message was parsed from a group history bundle shared by another group member
 
 	// If this event was parsed from a WebMessageInfo (i.e. from a history sync or unavailable message request), the source data is here.
 	SourceWebMsg *waWeb.WebMessageInfo
@@ -341,6 +344,32 @@ type Message struct {
 	RawMessage *waE2E.Message
 }
 
+// Album is emitted when all items of an album (grouped media message) have been received,
+// or when the album timeout passes before all the expected items arrive.
+//
+// This is only emitted if EmitAlbumEvents is set in the Client. The parent and child messages are
+// also emitted as normal Message events when they're received.
+type Album struct {
+	Info  types.MessageInfo   // Information about the parent album message
+	Album *waE2E.AlbumMessage // The parent album message, containing the expected item counts
+	// The child messages of the album in the order they should be displayed.
+	Items []*Message
+	// True if all the expected items were received. False if the timeout passed before receiving everything.
+	Complete bool
+}
+
+// GroupHistory is emitted when another member shares recent chat history with you after adding you to a group.
+//
+// This is only emitted if AutomaticGroupHistoryDownload is set in the Client, and only for bundles that list
+// this account as a receiver. Otherwise, use Client.DownloadGroupHistory to download bundles manually.
+// The message containing the bundle is also emitted as a normal Message event.
+type GroupHistory struct {
+	Info     types.MessageInfo // Information about the message containing the history bundle
+	Metadata *waE2E.MessageHistoryMetadata
+	// The messages in the bundle, sorted by timestamp. All the messages have IsSharedHistory set.
+	Messages []*Message
+}
+
 type FBMessage struct {
 	Info    types.MessageInfo               // Information about the message like the chat and sender IDs
 	Message armadillo.MessageApplicationSub // The actual message struct
@@ -413,6 +442,10 @@ func (evt *Message) UnwrapRaw() *Message {
 		evt.Message = evt.Message.GetEditedMessage().GetMessage()
 		evt.IsEdit = true
 	}
+	if evt.Message.GetAssociatedChildMessage().GetMessage() != nil {
+		evt.Message = evt.Message.GetAssociatedChildMessage().GetMessage()
+		evt.IsAssociatedChild = true
+	}
 	if evt.Message != nil && evt.RawMessage != nil && evt.Message.MessageContextInfo == nil && evt.RawMessage.MessageContextInfo != nil {
 		evt.Message.MessageContextInfo = evt.RawMessage.MessageContextInfo
 	}
@@ -563,6 +596,18 @@ type PrivacySettings struct {
 	MessagesChanged     bool
 	DefenseChanged      bool
 	StickersChanged     bool
+
+	// Changes to the exception lists of settings that use PrivacySettingContactBlacklist or PrivacySettingContactAllowlist.
+	ListChanges []PrivacyListChange
+}
+
+// PrivacyListChange contains the users added to or removed from the exception list of a privacy setting.
+type PrivacyListChange struct {
+	Setting types.PrivacySettingType
+	Value   types.PrivacySetting
+	DHash   string
+	Added   []types.JID
+	Removed []types.JID
 }
 
 // OfflineSyncPreview is emitted right after connecting if the server is going to send events that the client missed during downtime.
@@ -634,6 +679,15 @@ type MexNotificationData struct {
 	OpName    string
 }
 
+// MexNotification is emitted for MEX (GraphQL) notifications that don't have a more specific event type.
+type MexNotification struct {
+	Mex MexNotificationData
+	// The name of the field in the notification data, e.g. xwa2_notify_newsletter_on_state_change
+	Field string
+	// The raw JSON value of the field.
+	Data json.RawMessage
+}
+
 type NewsletterJoin struct {
 	Mex MexNotificationData `json:"-"`
 	types.NewsletterMetadata
@@ -651,12 +705,144 @@ type NewsletterMuteChange struct {
 	Mute types.NewsletterMuteState `json:"mute"`
 }
 
+// NewsletterAdminPromote is emitted when a user is promoted to admin in a newsletter you administer.
+type NewsletterAdminPromote struct {
+	Mex     MexNotificationData   `json:"-"`
+	ID      types.JID             `json:"id"`
+	User    types.NewsletterUser  `json:"user"`
+	Admin   *types.NewsletterUser `json:"admin"`
+	NewRole types.NewsletterRole  `json:"user_new_role"`
+	Actor   *types.NewsletterUser `json:"actor"`
+}
+
+// NewsletterAdminDemote is emitted when an admin is demoted in a newsletter you administer.
+type NewsletterAdminDemote struct {
+	Mex     MexNotificationData   `json:"-"`
+	ID      types.JID             `json:"id"`
+	User    types.NewsletterUser  `json:"user"`
+	Admin   *types.NewsletterUser `json:"admin"`
+	NewRole types.NewsletterRole  `json:"user_new_role"`
+	Actor   *types.NewsletterUser `json:"actor"`
+}
+
+// NewsletterAdminInviteRevoke is emitted when a pending admin invite to a newsletter is revoked.
+type NewsletterAdminInviteRevoke struct {
+	Mex   MexNotificationData   `json:"-"`
+	ID    types.JID             `json:"id"`
+	User  types.NewsletterUser  `json:"user"`
+	Actor *types.NewsletterUser `json:"actor"`
+}
+
+// NewsletterAdminMetadataUpdate is emitted when the admin-only metadata of a newsletter changes,
+// e.g. when the newsletter is restricted in some countries or there are issues delivering messages.
+type NewsletterAdminMetadataUpdate struct {
+	Mex        MexNotificationData                    `json:"-"`
+	ID         types.JID                              `json:"id"`
+	ThreadMeta *types.NewsletterAdminThreadMetadata   `json:"thread_metadata"`
+	Messages   types.NewsletterMessageDeliveryUpdates `json:"messages"`
+}
+
+// NewsletterOwnerUpdate is emitted to the owner of a newsletter when its metadata is changed by an admin.
+//
+// Only the changed fields in ThreadMeta are filled.
+type NewsletterOwnerUpdate struct {
+	Mex        MexNotificationData            `json:"-"`
+	ID         types.JID                      `json:"id"`
+	Actor      *types.NewsletterUser          `json:"actor"`
+	ThreadMeta types.NewsletterThreadMetadata `json:"thread_metadata"`
+}
+
+// NewsletterMetadataUpdate is emitted when the metadata of a newsletter you follow changes.
+//
+// Only the changed fields in ThreadMeta are filled.
+type NewsletterMetadataUpdate struct {
+	Mex        MexNotificationData            `json:"-"`
+	ID         types.JID                      `json:"id"`
+	ThreadMeta types.NewsletterThreadMetadata `json:"thread_metadata"`
+}
+
+// NewsletterStateChange is emitted when the state of a newsletter changes, e.g. when it's deleted or suspended.
+type NewsletterStateChange struct {
+	Mex         MexNotificationData          `json:"-"`
+	ID          types.JID                    `json:"id"`
+	IsRequestor bool                         `json:"is_requestor"`
+	State       types.WrappedNewsletterState `json:"state"`
+}
+
+// CommunityOwnerUpdate is emitted when the owner of a community changes.
+//
+// The new owner has the types.GroupParticipantRoleSuperAdmin role in RoleUpdates.
+type CommunityOwnerUpdate struct {
+	Mex         MexNotificationData                `json:"-"`
+	ID          types.JID                          `json:"id"`
+	UpdatedBy   *types.GroupMexUser                `json:"updated_by"`
+	UpdateTime  jsontime.UnixString                `json:"update_time"`
+	RoleUpdates []types.GroupParticipantRoleUpdate `json:"role_updates"`
+}
+
+// GroupParticipantLabelUpdate is emitted when a group participant changes their label (member tag).
+type GroupParticipantLabelUpdate struct {
+	Mex MexNotificationData `json:"-"`
+	// The participant who changed their label.
+	UpdatedBy  *types.GroupMexUser                  `json:"updated_by"`
+	UpdateTime jsontime.UnixString                  `json:"update_time"`
+	Update     types.GroupParticipantPropertyUpdate `json:"participant_property_update"`
+}
+
+// GroupPropertiesUpdate is emitted when group properties that are managed through GraphQL change,
+// such as whether participant labels are enabled.
+type GroupPropertiesUpdate struct {
+	Mex        MexNotificationData      `json:"-"`
+	ID         types.JID                `json:"id"`
+	UpdatedBy  *types.GroupMexUser      `json:"updated_by"`
+	UpdateTime jsontime.UnixString      `json:"update_time"`
+	Properties types.GroupMexProperties `json:"properties"`
+}
+
 type NewsletterLiveUpdate struct {
 	JID      types.JID
 	Time     time.Time
 	Messages []*types.NewsletterMessage
 }
 
+// TextStatusUpdate is emitted when a user changes their text status (about).
+//
+// Statuses with a duration expire locally, use [types.TextStatus.IsExpired] to check whether the status is still valid.
+type TextStatusUpdate struct {
+	Mex MexNotificationData `json:"-"`
+	types.TextStatus
+}
+
+// UsernameChange is emitted when a contact sets or changes their username.
+type UsernameChange struct {
+	Mex      MexNotificationData `json:"-"`
+	LID      types.JID           `json:"lid"`
+	Username string              `json:"username"`
+}
+
+// UsernameDelete is emitted when a contact removes their username.
+type UsernameDelete struct {
+	Mex         MexNotificationData `json:"-"`
+	LID         types.JID           `json:"lid"`
+	DisplayName string              `json:"display_name,omitempty"`
+	PhoneNumber types.JID           `json:"pn_jid,omitzero"`
+}
+
+// OwnUsernameUpdate is emitted when the username of your own account is changed from another device.
+//
+// The notification only contains a hash of the new username, use Client.GetOwnUsername to fetch the username itself.
+type OwnUsernameUpdate struct {
+	Mex  MexNotificationData `json:"-"`
+	Hash string              `json:"hash"`
+}
+
+// AccountSyncUsername is emitted when the server syncs the username and username PIN of your own account.
+type AccountSyncUsername struct {
+	Mex          MexNotificationData `json:"-"`
+	LID          types.JID           `json:"lid_jid,omitzero"`
+	UsernameInfo *types.UsernameInfo `json:"username_info"`
+}
+
 type NotifyAccountReachoutTimelock struct {
 	Mex                 MexNotificationData `json:"-"`
 	EnforcementType     string              `json:"enforcement_type,omitempty"`
@@ -8,6 +8,8 @@ package types
 
 import (
 	"time"
+
+	"go.mau.fi/util/jsontime"
 )
 
 type GroupMemberAddMode string
@@ -47,6 +49,8 @@ type GroupInfo struct {
 
 	// Suspended indicates whether the group is currently paused/suspended.
 	Suspended bool
+	// ParticipantLabelsEnabled indicates whether participants can set labels (member tags) for themselves in the group.
+	ParticipantLabelsEnabled bool
 }
 
 type GroupMembershipApprovalMode struct {
@@ -112,6 +116,8 @@ type GroupParticipant struct {
 
 	// This is only present for anonymous users in announcement groups, it's an obfuscated phone number
 	DisplayName string
+	// The label (member tag) the participant has set for themselves, if participant labels are enabled in the group.
+	Label string
 
 	// When creating groups, adding some participants may fail.
 	// In such cases, the error code will be here.
@@ -166,3 +172,56 @@ type GroupParticipantRequest struct {
 	JID         JID
 	RequestedAt time.Time
 }
+
+// GroupParticipantRole is the role of a participant in GraphQL group queries and notifications.
+type GroupParticipantRole string
+
+const (
+	GroupParticipantRoleMember     GroupParticipantRole = "MEMBER"
+	GroupParticipantRoleAdmin      GroupParticipantRole = "ADMIN"
+	GroupParticipantRoleSuperAdmin GroupParticipantRole = "SUPERADMIN"
+)
+
+// GroupMexUser is a user reference in GraphQL group notifications.
+type GroupMexUser struct {
+	ID         JID    `json:"id"`
+	PN         JID    `json:"pn"`
+	NotifyName string `json:"notify_name"`
+}
+
+// GroupParticipantRoleUpdate is a single participant role change in a GraphQL group notification.
+type GroupParticipantRoleUpdate struct {
+	User    JID                  `json:"user_jid"`
+	NewRole GroupParticipantRole `json:"new_role"`
+}
+
+// GroupMexProperties contains the group properties that can be changed in GraphQL group notifications.
+// Properties that weren't changed are nil.
+type GroupMexProperties struct {
+	ParticipantLabelEnabled *bool `json:"participant_label_enabled"`
+}
+
+// GroupParticipantLabel is the label (member tag) of a group participant.
+type GroupParticipantLabel struct {
+	Label      string        `json:"label"`
+	UpdateTime jsontime.Unix `json:"last_mtime_in_sec"`
+}
+
+// GroupParticipantPropertyUpdate contains the changed properties of a group participant.
+type GroupParticipantPropertyUpdate struct {
+	Group JID                    `json:"group_jid"`
+	Label *GroupParticipantLabel `json:"participant_label"`
+}
+
+// SuggestedGroup is a group that has been suggested to be added to a community.
+type SuggestedGroup struct {
+	JID              JID
+	Name             string
+	Description      string
+	Creator          JID
+	CreationTime     time.Time
+	ParticipantCount int
+	// Whether the suggestion is for an existing group rather than a new one.
+	IsExistingGroup bool
+	IsHidden        bool
+}
@@ -147,6 +147,200 @@ type NewsletterText struct {
 	UpdateTime jsontime.UnixMicroString `json:"update_time"`
 }
 
+// NewsletterUser is a user reference in newsletter admin responses and notifications.
+type NewsletterUser struct {
+	ID                   JID    `json:"id"`
+	PN                   JID    `json:"pn"`
+	DisplayName          string `json:"display_name,omitempty"`
+	ProfilePicDirectPath string `json:"profile_pic_direct_path,omitempty"`
+}
+
+// NewsletterPageInfo contains the cursors for paginated newsletter queries.
+type NewsletterPageInfo struct {
+	StartCursor     string `json:"startCursor"`
+	EndCursor       string `json:"endCursor"`
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+	HasNextPage     bool   `json:"hasNextPage"`
+}
+
+type NewsletterSubscriber struct {
+	User          NewsletterUser      `json:"node"`
+	Role          NewsletterRole      `json:"role"`
+	SubscribeTime jsontime.UnixString `json:"subscribe_time"`
+}
+
+// NewsletterSubscriberList is one page of the subscribers of a newsletter.
+// The next page can be requested using PageInfo.EndCursor if PageInfo.HasNextPage is true.
+type NewsletterSubscriberList struct {
+	PageInfo    NewsletterPageInfo     `json:"pageInfo"`
+	Subscribers []NewsletterSubscriber `json:"edges"`
+}
+
+type NewsletterReactionSender struct {
+	User NewsletterUser `json:"node"`
+	Role NewsletterRole `json:"role"`
+}
+
+// NewsletterReactionSenders contains the users who reacted to a newsletter message with a specific emoji.
+type NewsletterReactionSenders struct {
+	Reaction string
+	Senders  []NewsletterReactionSender
+}
+
+func (nrs *NewsletterReactionSenders) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Reaction   string `json:"reaction_code"`
+		SenderList struct {
+			Edges []NewsletterReactionSender `json:"edges"`
+		} `json:"sender_list"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	nrs.Reaction = raw.Reaction
+	nrs.Senders = raw.SenderList.Edges
+	return nil
+}
+
+type NewsletterPollVoter struct {
+	User       NewsletterUser      `json:"node"`
+	ActionTime jsontime.UnixString `json:"action_time"`
+}
+
+// NewsletterReactionSenderList is one page of the users who reacted to a newsletter message.
+// The next page can be requested using PageInfo.EndCursor if PageInfo.HasNextPage is true.
+type NewsletterReactionSenderList struct {
+	Reactions []NewsletterReactionSenders
+	PageInfo  NewsletterPageInfo
+}
+
+// NewsletterPollVoters contains the users who voted for a specific option in a newsletter poll.
+type NewsletterPollVoters struct {
+	// The hash of the option that was voted for (see whatsmeow.HashPollOptions).
+	VoteHash string
+	Voters   []NewsletterPollVoter
+}
+
+func (npv *NewsletterPollVoters) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		VoteHash  string `json:"vote_hash"`
+		VoterList struct {
+			Edges []NewsletterPollVoter `json:"edges"`
+		} `json:"voter_list"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	npv.VoteHash = raw.VoteHash
+	npv.Voters = raw.VoterList.Edges
+	return nil
+}
+
+// NewsletterPollVoterList is one page of the users who voted in a newsletter poll.
+// The next page can be requested using PageInfo.EndCursor if PageInfo.HasNextPage is true.
+type NewsletterPollVoterList struct {
+	Votes    []NewsletterPollVoters
+	PageInfo NewsletterPageInfo
+}
+
+// NewsletterInsightMetric is the ID of a metric in newsletter insights.
+type NewsletterInsightMetric int
+
+type NewsletterInsightValue struct {
+	Value     float64             `json:"value"`
+	Country   string              `json:"country"`
+	Role      NewsletterRole      `json:"role"`
+	Timestamp jsontime.UnixString `json:"timestamp"`
+}
+
+type NewsletterInsightResult struct {
+	Metric NewsletterInsightMetric  `json:"id"`
+	Values []NewsletterInsightValue `json:"values"`
+}
+
+// NewsletterInsights contains engagement statistics of a newsletter, such as views and follower growth.
+type NewsletterInsights struct {
+	LastUpdateTime jsontime.UnixString       `json:"last_update_time"`
+	MetricsStatus  string                    `json:"metrics_status"`
+	Results        []NewsletterInsightResult `json:"result"`
+}
+
+type NewsletterGeoState struct {
+	CountryCode string                 `json:"country_code"`
+	State       WrappedNewsletterState `json:"state"`
+}
+
+// NewsletterAdminThreadMetadata contains the parts of newsletter metadata that are only visible to admins.
+type NewsletterAdminThreadMetadata struct {
+	GeoStates []NewsletterGeoState `json:"geo_states"`
+}
+
+type NewsletterMessageDeliveryUpdate struct {
+	ServerID  MessageServerID
+	IssueCode string
+}
+
+// NewsletterMessageDeliveryUpdates is a list of delivery issues with messages in a newsletter.
+type NewsletterMessageDeliveryUpdates []NewsletterMessageDeliveryUpdate
+
+func (nmdu *NewsletterMessageDeliveryUpdates) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Edges []struct {
+			Node struct {
+				ServerID       MessageServerID `json:"server_id,string"`
+				DeliveryUpdate struct {
+					Issue struct {
+						Code string `json:"code"`
+					} `json:"issue"`
+				} `json:"message_delivery_update"`
+			} `json:"node"`
+		} `json:"edges"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*nmdu = make(NewsletterMessageDeliveryUpdates, len(raw.Edges))
+	for i, edge := range raw.Edges {
+		(*nmdu)[i] = NewsletterMessageDeliveryUpdate{
+			ServerID:  edge.Node.ServerID,
+			IssueCode: edge.Node.DeliveryUpdate.Issue.Code,
+		}
+	}
+	return nil
+}
+
+// NewsletterDirectoryView is a view of the newsletter directory.
+type NewsletterDirectoryView string
+
+const (
+	NewsletterDirectoryViewRecommended NewsletterDirectoryView = "RECOMMENDED"
+	NewsletterDirectoryViewTrending    NewsletterDirectoryView = "TRENDING"
+	NewsletterDirectoryViewPopular     NewsletterDirectoryView = "POPULAR"
+	NewsletterDirectoryViewNew         NewsletterDirectoryView = "NEW"
+)
+
+// NewsletterDirectoryCategory is a category in the newsletter directory.
+// The available categories can be fetched with whatsmeow.Client.GetNewsletterDirectoryCategories.
+type NewsletterDirectoryCategory string
+
+// NewsletterDirectoryPage is one page of newsletters from the directory.
+//
+// The next page can be requested using PageInfo.EndCursor. If PageInfo is nil or EndCursor is empty, there are no more pages.
+type NewsletterDirectoryPage struct {
+	Newsletters []*NewsletterMetadata `json:"result"`
+	PageInfo    *NewsletterPageInfo   `json:"page_info"`
+}
+
+// NewsletterDirectoryCategoryPreview contains the top newsletters in a directory category.
+type NewsletterDirectoryCategoryPreview struct {
+	Category    NewsletterDirectoryCategory `json:"category"`
+	Title       string                      `json:"category_title"`
+	Newsletters []*NewsletterMetadata       `json:"newsletters"`
+}
+
 type NewsletterMessage struct {
 	MessageServerID MessageServerID
 	MessageID       MessageID
@@ -8,6 +8,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 )
 
 type Presence string
@@ -31,6 +32,23 @@ const (
 	ChatPresenceMediaAudio ChatPresenceMedia = "audio"
 )
 
+// PresenceInfo is the last known presence of a user.
+type PresenceInfo struct {
+	Online bool
+	// The time when the user was last online. This may be the zero value if the user has hid their last seen time.
+	LastSeen time.Time
+	// The time when the presence update was received.
+	UpdatedAt time.Time
+}
+
+// TypingUser is a user who is currently typing or recording in a chat.
+type TypingUser struct {
+	JID   JID
+	Media ChatPresenceMedia
+	// The time when the last composing update from the user was received.
+	UpdatedAt time.Time
+}
+
 // ReceiptType represents the type of a Receipt event.
 type ReceiptType string
 
@@ -81,6 +81,44 @@ type ContactInfo struct {
 	RedactedPhone string
 }
 
+// DisplayNameSource is where a display name was found, see Client.GetDisplayName.
+type DisplayNameSource string
+
+const (
+	// The full name or first name saved in the address book.
+	DisplayNameSourceContact DisplayNameSource = "contact"
+	// The verified business name.
+	DisplayNameSourceBusiness DisplayNameSource = "business"
+	// The name the user has set for themselves.
+	DisplayNameSourcePushName DisplayNameSource = "push_name"
+	// The participant label (member tag) the user has set for themselves in the group.
+	DisplayNameSourceGroupLabel DisplayNameSource = "group_label"
+	// The username of the user, formatted as @username.
+	DisplayNameSourceUsername DisplayNameSource = "username"
+	// The partially hidden phone number of a LID user, e.g. +1∙∙∙∙∙∙∙∙80.
+	DisplayNameSourceRedactedPhone DisplayNameSource = "redacted_phone"
+	// The phone number of the user, formatted as +<number>.
+	DisplayNameSourcePhone DisplayNameSource = "phone"
+	// The user part of the JID, used as a last resort.
+	DisplayNameSourceJID DisplayNameSource = "jid"
+)
+
+// DisplayName is the resolved name of a user, see Client.GetDisplayName.
+type DisplayName struct {
+	Name   string
+	Source DisplayNameSource
+	// The participant label (member tag) of the user in the given group, if any.
+	GroupLabel string
+	// Both identities of the user, if known.
+	LID         JID
+	PhoneNumber JID
+}
+
+// String returns the display name.
+func (dn *DisplayName) String() string {
+	return dn.Name
+}
+
 // LocalChatSettings contains the cached local settings for a chat.
 type LocalChatSettings struct {
 	Found bool
@@ -167,6 +205,16 @@ type PrivacySettings struct {
 	Stickers     PrivacySetting // Valid values: PrivacySettingContacts, PrivacySettingContactAllowlist, PrivacySettingNone
 }
 
+// PrivacyList contains the exceptions of a privacy setting, i.e. the users who are excluded when the setting
+// is PrivacySettingContactBlacklist, or the users who are included when it's PrivacySettingContactAllowlist.
+type PrivacyList struct {
+	Setting PrivacySettingType
+	Value   PrivacySetting
+	// The hash of the current list. It's used to detect concurrent changes when updating the list.
+	DHash string
+	JIDs  []JID
+}
+
 // StatusPrivacyType is the type of list in StatusPrivacy.
 type StatusPrivacyType string
 
@@ -197,6 +245,29 @@ type SetStatusInput struct {
 	Duration jsontime.Seconds `json:"ephemeral_duration_sec"`
 }
 
+// TextStatus is the text status (about) of a user, which may include an emoji and expire after some time.
+type TextStatus struct {
+	JID        JID                 `json:"jid"`
+	Text       string              `json:"text"`
+	Emoji      *SetStatusEmoji     `json:"emoji,omitempty"`
+	Duration   jsontime.Seconds    `json:"ephemeral_duration_sec"`
+	LastUpdate jsontime.UnixString `json:"last_update_time"`
+}
+
+// ExpiresAt returns the time when the status expires, or a zero time if it doesn't expire.
+func (ts *TextStatus) ExpiresAt() time.Time {
+	if ts.Duration.Duration <= 0 || ts.LastUpdate.IsZero() {
+		return time.Time{}
+	}
+	return ts.LastUpdate.Add(ts.Duration.Duration)
+}
+
+// IsExpired returns true if the status has expired at the given time.
+func (ts *TextStatus) IsExpired(now time.Time) bool {
+	expiry := ts.ExpiresAt()
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
 // Blocklist contains the user's current list of blocked users.
 type Blocklist struct {
 	DHash string // TODO is this just a timestamp?
@@ -222,8 +293,26 @@ type BusinessProfile struct {
 	JID                   JID
 	Address               string
 	Email                 string
+	Description           string
+	Websites              []string
 	Categories            []Category
 	ProfileOptions        map[string]string
 	BusinessHoursTimeZone string
 	BusinessHours         []BusinessHoursConfig
 }
+
+// UsernameCheckResult is the result of checking whether a username can be used, see Client.CheckUsername.
+type UsernameCheckResult struct {
+	// The availability of the username, e.g. "AVAILABLE" or "TAKEN".
+	Result string `json:"result"`
+	// Similar usernames that are available, if the requested one isn't.
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// UsernameInfo contains the username settings of your own account.
+type UsernameInfo struct {
+	Username string `json:"username,omitempty"`
+	// The PIN that users who don't have your phone number need to enter to message you.
+	PIN    string `json:"pin,omitempty"`
+	Status string `json:"status,omitempty"`
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// TypingRefreshInterval is how often typing sessions resend the composing state,
+// so that it doesn't time out on the receiving side.
+const TypingRefreshInterval = 10 * time.Second
+
+var ErrPresenceUnavailable = errors.New("own presence is not available, sending typing state would reveal online status")
+
+// TypingSession is a typing indicator in a chat that's kept alive until it's stopped. See [Client.StartTyping].
+type TypingSession struct {
+	cli   *Client
+	chat  types.JID
+	media types.ChatPresenceMedia
+
+	cancel   context.CancelFunc
+	stopOnce sync.Once
+}
+
+// StartTyping starts showing a typing indicator in the given chat. If media is [types.ChatPresenceMediaAudio],
+// the indicator says that you're recording audio instead.
+//
+// The composing state is refreshed in the background until one of the following happens:
+//   - [TypingSession.Stop] is called.
+//   - A new message is sent to the chat with [Client.SendMessage]. If sending fails, the paused state is sent.
+//     Reactions, edits, revokes and other updates to existing messages don't end the session.
+//   - The given context is canceled, which also sends the paused state.
+//   - Your own presence is changed to unavailable with [Client.SendPresence], or the connection is closed.
+//   - A new typing session is started in the same chat.
+//
+// To avoid revealing that you're online, this returns ErrPresenceUnavailable unless
+// you've marked yourself as available with [Client.SendPresence] after the current connection was established.
+func (cli *Client) StartTyping(ctx context.Context, chat types.JID, media types.ChatPresenceMedia) (*TypingSession, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	} else if !cli.presenceAvailable.Load() {
+		return nil, ErrPresenceUnavailable
+	}
+	chat = chat.ToNonAD()
+	err := cli.SendChatPresence(ctx, chat, types.ChatPresenceComposing, media)
+	if err != nil {
+		return nil, err
+	}
+	sessionCtx, cancel := context.WithCancel(ctx)
+	session := &TypingSession{
+		cli:    cli,
+		chat:   chat,
+		media:  media,
+		cancel: cancel,
+	}
+	cli.typingSessionsLock.Lock()
+	existing := cli.typingSessions[chat]
+	cli.typingSessions[chat] = session
+	cli.typingSessionsLock.Unlock()
+	if existing != nil {
+		existing.finish(false)
+	}
+	go session.loop(sessionCtx)
+	return session, nil
+}
+
+// Stop stops refreshing the typing indicator and sends the paused state.
+// It's safe to call this multiple times and after the session has already ended.
+func (ts *TypingSession) Stop() {
+	ts.cli.removeTypingSession(ts)
+	ts.finish(true)
+}
+
+func (ts *TypingSession) finish(sendPaused bool) {
+	ts.stopOnce.Do(func() {
+		ts.cancel()
+		if sendPaused && ts.cli.presenceAvailable.Load() {
+			err := ts.cli.SendChatPresence(ts.cli.BackgroundEventCtx, ts.chat, types.ChatPresencePaused, "")
+			if err != nil {
+				ts.cli.Log.Debugf("Failed to send paused state to %s: %v", ts.chat, err)
+			}
+		}
+	})
+}
+
+func (ts *TypingSession) loop(ctx context.Context) {
+	ticker := time.NewTicker(TypingRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Only send the paused state if the session wasn't already finished some other way
+			ts.cli.removeTypingSession(ts)
+			ts.finish(true)
+			return
+		case <-ticker.C:
+			if !ts.cli.presenceAvailable.Load() {
+				ts.cli.removeTypingSession(ts)
+				ts.finish(false)
+				return
+			}
+			err := ts.cli.SendChatPresence(ctx, ts.chat, types.ChatPresenceComposing, ts.media)
+			if err != nil && ctx.Err() == nil {
+				ts.cli.Log.Debugf("Failed to refresh typing state in %s: %v", ts.chat, err)
+			}
+		}
+	}
+}
+
+func (cli *Client) removeTypingSession(ts *TypingSession) {
+	cli.typingSessionsLock.Lock()
+	defer cli.typingSessionsLock.Unlock()
+	if cli.typingSessions[ts.chat] == ts {
+		delete(cli.typingSessions, ts.chat)
+	}
+}
+
+// endsTypingSession returns true if sending the given message should end the typing session in the chat,
+// i.e. if it's a new message rather than a reaction, edit, revoke or other update to an existing message.
+func endsTypingSession(msg *waE2E.Message) bool {
+	return msg.ReactionMessage == nil && msg.EncReactionMessage == nil &&
+		msg.ProtocolMessage == nil && msg.EditedMessage == nil &&
+		msg.PollUpdateMessage == nil && msg.EncEventResponseMessage == nil &&
+		msg.KeepInChatMessage == nil && msg.PinInChatMessage == nil
+}
+
+func (cli *Client) endTypingSession(chat types.JID, sendPaused bool) {
+	chat = chat.ToNonAD()
+	cli.typingSessionsLock.Lock()
+	session, ok := cli.typingSessions[chat]
+	delete(cli.typingSessions, chat)
+	cli.typingSessionsLock.Unlock()
+	if ok {
+		session.finish(sendPaused)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -37,6 +38,11 @@ type UploadResponse struct {
 	FileEncSHA256 []byte `json:"-"`
 	FileSHA256    []byte `json:"-"`
 	FileLength    uint64 `json:"-"`
+
+	// StreamingSidecar contains per-chunk MACs that allow other clients to stream the file.
+	// It's only generated for video and audio files, and should be copied to the StreamingSidecar field
+	// of VideoMessage and AudioMessage.
+	StreamingSidecar []byte `json:"-"`
 }
 
 // Upload uploads the given attachment to WhatsApp servers.
@@ -90,6 +96,14 @@ func (cli *Client) Upload(ctx context.Context, plaintext []byte, appInfo MediaTy
 	dataHash := sha256.Sum256(dataToUpload)
 	resp.FileEncSHA256 = dataHash[:]
 
+	if mediaTypeHasStreamingSidecar(appInfo) {
+		resp.StreamingSidecar, err = generateStreamingSidecar(iv, macKey, bytes.NewReader(ciphertext))
+		if err != nil {
+			err = fmt.Errorf("failed to generate streaming sidecar: %w", err)
+			return
+		}
+	}
+
 	err = cli.rawUpload(ctx, bytes.NewReader(dataToUpload), uint64(len(dataToUpload)), resp.FileEncSHA256, appInfo, false, &resp)
 	return
 }
@@ -101,6 +115,8 @@ func (cli *Client) Upload(ctx context.Context, plaintext []byte, appInfo MediaTy
 // and deleted after the upload.
 //
 // To use only one file, pass the same file as both plaintext and tempFile. This will cause the file to be overwritten with encrypted data.
+//
+// For video and audio files, a streaming sidecar is also generated from the encrypted data.
 func (cli *Client) UploadReader(ctx context.Context, plaintext io.Reader, tempFile io.ReadWriteSeeker, appInfo MediaType) (resp UploadResponse, err error) {
 	resp.MediaKey = random.Bytes(32)
 	iv, cipherKey, macKey, _ := getMediaKeys(resp.MediaKey, appInfo)
@@ -127,6 +143,18 @@ func (cli *Client) UploadReader(ctx context.Context, plaintext io.Reader, tempFi
 		err = fmt.Errorf("failed to seek to start of temporary file: %w", err)
 		return
 	}
+	if mediaTypeHasStreamingSidecar(appInfo) {
+		resp.StreamingSidecar, err = generateStreamingSidecar(iv, macKey, io.LimitReader(tempFile, int64(uploadSize-mediaHMACLength)))
+		if err != nil {
+			err = fmt.Errorf("failed to generate streaming sidecar: %w", err)
+			return
+		}
+		_, err = tempFile.Seek(0, io.SeekStart)
+		if err != nil {
+			err = fmt.Errorf("failed to seek to start of temporary file: %w", err)
+			return
+		}
+	}
 	err = cli.rawUpload(ctx, tempFile, uploadSize, resp.FileEncSHA256, appInfo, false, &resp)
 	return
 }
@@ -194,16 +222,10 @@ func (cli *Client) UploadNewsletterReader(ctx context.Context, data io.ReadSeeke
 }
 
 func (cli *Client) rawUpload(ctx context.Context, dataToUpload io.Reader, uploadSize uint64, fileHash []byte, appInfo MediaType, newsletter bool, resp *UploadResponse) error {
-	mediaConn, err := cli.refreshMediaConn(ctx, false)
-	if err != nil {
-		return fmt.Errorf("failed to refresh media connections: %w", err)
-	}
+	return cli.uploadToPath(ctx, dataToUpload, uploadSize, fileHash, cli.getMediaUploadPath(appInfo, newsletter), resp)
+}
 
-	token := base64.URLEncoding.EncodeToString(fileHash)
-	q := url.Values{
-		"auth":  []string{mediaConn.Auth},
-		"token": []string{token},
-	}
+func (cli *Client) getMediaUploadPath(appInfo MediaType, newsletter bool) string {
 	mmsType := mediaTypeToMMSType[appInfo]
 	uploadPrefix := "mms"
 	if cli.MessengerConfig != nil {
@@ -217,22 +239,62 @@ func (cli *Client) rawUpload(ctx context.Context, dataToUpload io.Reader, upload
 		mmsType = fmt.Sprintf("newsletter-%s", mmsType)
 		uploadPrefix = "newsletter"
 	}
+	return fmt.Sprintf("%s/%s", uploadPrefix, mmsType)
+}
+
+func (cli *Client) uploadToPath(ctx context.Context, dataToUpload io.Reader, uploadSize uint64, fileHash []byte, uploadPath string, resp *UploadResponse) error {
+	mediaConn, err := cli.refreshMediaConn(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to refresh media connections: %w", err)
+	}
+	err = cli.doRawUpload(ctx, mediaConn, dataToUpload, uploadSize, fileHash, uploadPath, resp)
+	if !errors.Is(err, errMediaAuthExpired) {
+		return err
+	}
+	// The server rejected the media auth token, fetch a new one and try again if the data can be re-read.
+	seeker, ok := dataToUpload.(io.Seeker)
+	if !ok {
+		return err
+	} else if _, seekErr := seeker.Seek(0, io.SeekStart); seekErr != nil {
+		return err
+	}
+	cli.Log.Debugf("Media upload auth expired, refreshing media connection and retrying")
+	mediaConn, err = cli.refreshMediaConn(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to refresh media connections: %w", err)
+	}
+	return cli.doRawUpload(ctx, mediaConn, dataToUpload, uploadSize, fileHash, uploadPath, resp)
+}
+
+var errMediaAuthExpired = errors.New("media auth token expired")
+
+func (cli *Client) doRawUpload(ctx context.Context, mediaConn *MediaConn, dataToUpload io.Reader, uploadSize uint64, fileHash []byte, uploadPath string, resp *UploadResponse) error {
+	token := base64.URLEncoding.EncodeToString(fileHash)
+	q := url.Values{
+		"auth":  []string{mediaConn.Auth},
+		"token": []string{token},
+	}
 	var host string
 	// Hacky hack to prefer last option (rupload.facebook.com) for messenger uploads.
 	// For some reason, the primary host doesn't work, even though it has the <upload/> tag.
 	if cli.MessengerConfig != nil {
 		host = mediaConn.Hosts[len(mediaConn.Hosts)-1].Hostname
 	} else {
-		host = mediaConn.Hosts[0].Hostname
+		host = cli.getSortedMediaHosts(mediaConn)[0].Hostname
 	}
 	uploadURL := url.URL{
 		Scheme:   "https",
 		Host:     host,
-		Path:     fmt.Sprintf("/%s/%s/%s", uploadPrefix, mmsType, token),
+		Path:     fmt.Sprintf("/%s/%s", uploadPath, token),
 		RawQuery: q.Encode(),
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL.String(), dataToUpload)
+	body := dataToUpload
+	if _, isCloser := body.(io.Closer); isCloser {
+		// Don't let the HTTP client close files, the upload may need to be retried with a new auth token
+		body = io.NopCloser(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL.String(), body)
 	if err != nil {
 		return fmt.Errorf("failed to prepare request: %w", err)
 	}
@@ -241,9 +303,11 @@ func (cli *Client) rawUpload(ctx context.Context, dataToUpload io.Reader, upload
 	req.Header.Set("Origin", socket.Origin)
 	req.Header.Set("Referer", socket.Origin+"/")
 
-	httpResp, err := cli.mediaHTTP.Do(req)
+	httpResp, err := cli.doMediaRequest(req)
 	if err != nil {
 		err = fmt.Errorf("failed to execute request: %w", err)
+	} else if httpResp.StatusCode == http.StatusUnauthorized {
+		err = fmt.Errorf("%w (upload failed with status code %d)", errMediaAuthExpired, httpResp.StatusCode)
 	} else if httpResp.StatusCode != http.StatusOK {
 		err = fmt.Errorf("upload failed with status code %d", httpResp.StatusCode)
 	} else if err = json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
@@ -283,7 +347,7 @@ func (cli *Client) DeleteMedia(ctx context.Context, appInfo MediaType, directPat
 	}
 	deleteURL := url.URL{
 		Scheme:   "https",
-		Host:     mediaConn.Hosts[0].Hostname,
+		Host:     cli.getSortedMediaHosts(mediaConn)[0].Hostname,
 		Path:     fmt.Sprintf("/mms/%s/%s", mediaTypeToMMSType[appInfo], token),
 		RawQuery: query.Encode(),
 	}
@@ -299,7 +363,7 @@ func (cli *Client) DeleteMedia(ctx context.Context, appInfo MediaType, directPat
 		req.Header.Set("Companion_User_Secret", cli.Store.CompanionMetaNonce)
 	}
 
-	httpResp, err := cli.mediaHTTP.Do(req)
+	httpResp, err := cli.doMediaRequest(req)
 	if err != nil {
 		err = fmt.Errorf("failed to execute request: %w", err)
 	} else if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
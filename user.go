@@ -390,6 +390,13 @@ func (cli *Client) parseBusinessProfile(node *waBinary.Node) (*types.BusinessPro
 	}
 	address, _ := profileNode.GetChildByTag("address").Content.([]byte)
 	email, _ := profileNode.GetChildByTag("email").Content.([]byte)
+	description, _ := profileNode.GetChildByTag("description").Content.([]byte)
+	var websites []string
+	for _, website := range profileNode.GetChildrenByTag("website") {
+		if websiteBytes, ok := website.Content.([]byte); ok && len(websiteBytes) > 0 {
+			websites = append(websites, string(websiteBytes))
+		}
+	}
 	businessHour := profileNode.GetChildByTag("business_hours")
 	businessHourTimezone := businessHour.AttrGetter().String("timezone")
 	businessHoursConfigs := businessHour.GetChildren()
@@ -433,6 +440,8 @@ func (cli *Client) parseBusinessProfile(node *waBinary.Node) (*types.BusinessPro
 		JID:                   jid,
 		Email:                 string(email),
 		Address:               string(address),
+		Description:           string(description),
+		Websites:              websites,
 		Categories:            categories,
 		ProfileOptions:        profileOptions,
 		BusinessHoursTimeZone: businessHourTimezone,
@@ -927,6 +936,10 @@ func (cli *Client) usync(ctx context.Context, jids []types.JID, mode, context st
 			return nil, fmt.Errorf("unknown user server '%s'", jid.Server)
 		}
 	}
+	return cli.sendUsync(ctx, mode, context, query, userList)
+}
+
+func (cli *Client) sendUsync(ctx context.Context, mode, context string, query, userList []waBinary.Node) (*waBinary.Node, error) {
 	resp, err := cli.sendIQ(ctx, infoQuery{
 		Namespace: "usync",
 		Type:      "get",
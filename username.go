@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+)
+
+var ErrUsernameNotFound = errors.New("username not found")
+
+// NormalizeUsername converts a username into the form used by WhatsApp, i.e. lowercase without the @ prefix.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(username), "@"))
+}
+
+type respUsernameCheck struct {
+	Check *types.UsernameCheckResult `json:"xwa2_username_check"`
+}
+
+// CheckUsername checks whether the given username is available for your account.
+func (cli *Client) CheckUsername(ctx context.Context, username string) (*types.UsernameCheckResult, error) {
+	resp, err := MexQuery[respUsernameCheck](ctx, cli, "UsernameCheck", map[string]any{
+		"username": NormalizeUsername(username),
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Check == nil {
+		return nil, &ElementMissingError{Tag: "xwa2_username_check", In: "response to username check"}
+	}
+	return resp.Check, nil
+}
+
+type respUsernameGet struct {
+	Get *struct {
+		Username string `json:"username"`
+	} `json:"xwa2_username_get"`
+}
+
+// GetOwnUsername gets the username of your own account. An empty string means no username is set.
+func (cli *Client) GetOwnUsername(ctx context.Context) (string, error) {
+	resp, err := MexQuery[respUsernameGet](ctx, cli, "UsernameGet", map[string]any{})
+	if err != nil {
+		return "", err
+	} else if resp.Get == nil {
+		return "", nil
+	}
+	return resp.Get.Username, nil
+}
+
+type respUsernameSet struct {
+	Set *struct {
+		Result string `json:"result"`
+	} `json:"xwa2_username_set"`
+}
+
+// SetUsername sets the username of your own account. Use [Client.CheckUsername] first to make sure it's available.
+//
+// The returned string is the result code from the server.
+func (cli *Client) SetUsername(ctx context.Context, username string) (string, error) {
+	return cli.setUsername(ctx, NormalizeUsername(username))
+}
+
+// RemoveUsername removes the username of your own account.
+func (cli *Client) RemoveUsername(ctx context.Context) (string, error) {
+	return cli.setUsername(ctx, "")
+}
+
+func (cli *Client) setUsername(ctx context.Context, username string) (string, error) {
+	var usernameVar any
+	if username != "" {
+		usernameVar = username
+	}
+	resp, err := MexQuery[respUsernameSet](ctx, cli, "UsernameSet", map[string]any{
+		"username": usernameVar,
+	})
+	if err != nil {
+		return "", err
+	} else if resp.Set == nil {
+		return "", nil
+	}
+	return resp.Set.Result, nil
+}
+
+type respUsernamePinSet struct {
+	Set *struct {
+		Result string `json:"result"`
+	} `json:"xwa2_username_pin_set"`
+}
+
+// SetUsernamePIN sets the PIN that users who don't have your phone number need to enter when they
+// message you by username for the first time. An empty PIN removes the requirement.
+//
+// The returned string is the result code from the server.
+func (cli *Client) SetUsernamePIN(ctx context.Context, pin string) (string, error) {
+	var pinVar any
+	if pin != "" {
+		pinVar = pin
+	}
+	resp, err := MexQuery[respUsernamePinSet](ctx, cli, "UsernamePinSet", map[string]any{
+		"pin": pinVar,
+	})
+	if err != nil {
+		return "", err
+	} else if resp.Set == nil {
+		return "", nil
+	}
+	return resp.Set.Result, nil
+}
+
+// ResolveUsername finds the LID of the user with the given username. The pin is only required if the user has set one
+// and you don't have their phone number. The returned LID can be used with [Client.SendMessage] directly.
+//
+// Successful lookups are stored in the LID store, see [store.LIDStore.GetLIDForUsername].
+func (cli *Client) ResolveUsername(ctx context.Context, username, pin string) (types.JID, error) {
+	username = NormalizeUsername(username)
+	usernameNode := waBinary.Node{Tag: "username", Content: username}
+	if pin != "" {
+		usernameNode.Attrs = waBinary.Attrs{"pin": pin}
+	}
+	list, err := cli.sendUsync(ctx, "query", "interactive", []waBinary.Node{
+		{Tag: "username"},
+		{Tag: "lid"},
+	}, []waBinary.Node{{
+		Tag:     "user",
+		Content: []waBinary.Node{usernameNode},
+	}})
+	if err != nil {
+		return types.JID{}, err
+	}
+	for _, child := range list.GetChildren() {
+		if child.Tag != "user" {
+			continue
+		}
+		ag := child.AttrGetter()
+		jid := ag.OptionalJIDOrEmpty("jid")
+		if lidNode, ok := child.GetOptionalChildByTag("lid"); ok && jid.Server != types.HiddenUserServer {
+			if lid := lidNode.AttrGetter().OptionalJIDOrEmpty("val"); !lid.IsEmpty() {
+				jid = lid
+			}
+		}
+		if errNode, ok := child.GetOptionalChildByTag("username", "error"); ok {
+			errAG := errNode.AttrGetter()
+			return types.JID{}, fmt.Errorf("%w: %d %s", ErrUsernameNotFound, errAG.OptionalInt("code"), errAG.OptionalString("text"))
+		} else if jid.IsEmpty() {
+			continue
+		}
+		cli.storeUsernameMapping(ctx, jid, username)
+		return jid, nil
+	}
+	return types.JID{}, ErrUsernameNotFound
+}
+
+func (cli *Client) storeUsernameMapping(ctx context.Context, lid types.JID, username string) {
+	if lid.Server != types.HiddenUserServer {
+		return
+	}
+	err := cli.Store.LIDs.PutUsernameMapping(ctx, lid, username)
+	if err != nil {
+		cli.Log.Warnf("Failed to store username mapping for %s: %v", lid, err)
+	}
+}